@@ -0,0 +1,166 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unix
+
+// This program can be used as go_cosmo_$GOARCH_exec by the Go tool.
+// It runs cosmo binaries on a Unix host through the APE launcher.
+//
+// An APE binary starts with a shell script that finds and starts the
+// native code for the host. Shells run it, but execve fails with
+// ENOEXEC unless the host has registered a binfmt_misc handler for it,
+// so binaries started by the go command need a launcher. The wrapper
+// uses the ape loader if one is installed and otherwise hands the
+// binary to /bin/sh.
+package main
+
+import (
+	"errors"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// timeoutGrace is how long past its -test.timeout a test binary may
+// run before the wrapper sends it SIGQUIT. The test binary normally
+// panics on its own well before that.
+const timeoutGrace = 1 * time.Minute
+
+// killDelay is how long the wrapper waits after SIGQUIT before it
+// kills the binary.
+const killDelay = 5 * time.Second
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("go_cosmo_exec: ")
+	if len(os.Args) < 2 {
+		log.Fatal("usage: go_cosmo_exec a.out [arguments...]")
+	}
+	// C programs linked against a Go c-archive by a toolchain other
+	// than cosmocc are plain ELF. They replace the wrapper, so that
+	// they see signals and exit just as they would natively.
+	if isELF(os.Args[1]) {
+		log.Fatal(syscall.Exec(os.Args[1], os.Args[1:], os.Environ()))
+	}
+	exitCode, err := runMain(os.Args[1], os.Args[2:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	os.Exit(exitCode)
+}
+
+func runMain(bin string, args []string) (int, error) {
+	cmd := launcherCmd(bin, args)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	// Forward signals from the go command, in particular the SIGQUIT
+	// it sends on timeout, so that backtraces come from the binary
+	// instead of from this wrapper.
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGHUP, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM)
+	defer signal.Stop(sigc)
+
+	if err := cmd.Start(); err != nil {
+		return 0, err
+	}
+	go func() {
+		for sig := range sigc {
+			cmd.Process.Signal(sig)
+		}
+	}()
+
+	if d := testTimeout(args); d > 0 {
+		timer := time.AfterFunc(d+timeoutGrace, func() {
+			log.Printf("%s still running %v after -test.timeout; sending SIGQUIT", bin, timeoutGrace)
+			cmd.Process.Signal(syscall.SIGQUIT)
+			time.AfterFunc(killDelay, func() { cmd.Process.Kill() })
+		})
+		defer timer.Stop()
+	}
+
+	err := cmd.Wait()
+	var ee *exec.ExitError
+	if err != nil && !errors.As(err, &ee) {
+		return 0, err
+	}
+	ws := cmd.ProcessState.Sys().(syscall.WaitStatus)
+	if ws.Signaled() {
+		// Die by the same signal, so the go command sees
+		// the binary's fate rather than our own.
+		sig := ws.Signal()
+		signal.Reset(sig)
+		syscall.Kill(os.Getpid(), sig)
+		return 128 + int(sig), nil
+	}
+	return ws.ExitStatus(), nil
+}
+
+// launcherCmd returns the command that runs the APE binary bin with
+// the given arguments.
+func launcherCmd(bin string, args []string) *exec.Cmd {
+	if ape := apeLoader(); ape != "" {
+		return exec.Command(ape, append([]string{bin}, args...)...)
+	}
+	// The shell script at the start of the binary does the rest.
+	// It execs the native code, so the process started here is the
+	// one that eventually runs the program.
+	return exec.Command("/bin/sh", append([]string{bin}, args...)...)
+}
+
+// isELF reports whether the file bin starts with the ELF magic.
+func isELF(bin string) bool {
+	f, err := os.Open(bin)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(f, magic); err != nil {
+		return false
+	}
+	return string(magic) == "\x7fELF"
+}
+
+// apeLoader returns the path of the installed ape loader, or "".
+func apeLoader() string {
+	if path, err := exec.LookPath("ape"); err == nil {
+		return path
+	}
+	for _, path := range []string{"/usr/bin/ape", "/usr/local/bin/ape"} {
+		if fi, err := os.Stat(path); err == nil && fi.Mode()&0111 != 0 {
+			return path
+		}
+	}
+	return ""
+}
+
+// testTimeout returns the value of the -test.timeout flag in args,
+// or 0 if there is none.
+func testTimeout(args []string) time.Duration {
+	for i, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		name, value, ok := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if name != "test.timeout" {
+			continue
+		}
+		if !ok && i+1 < len(args) {
+			value = args[i+1]
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return 0
+		}
+		return d
+	}
+	return 0
+}
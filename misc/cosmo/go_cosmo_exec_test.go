@@ -0,0 +1,82 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestTestTimeout(t *testing.T) {
+	tests := []struct {
+		args []string
+		want time.Duration
+	}{
+		{nil, 0},
+		{[]string{"-test.v"}, 0},
+		{[]string{"-test.v", "-test.timeout=10m0s"}, 10 * time.Minute},
+		{[]string{"--test.timeout=3s", "-test.run=X"}, 3 * time.Second},
+		{[]string{"-test.timeout", "90s"}, 90 * time.Second},
+		{[]string{"-test.timeout=bogus"}, 0},
+		{[]string{"-test.timeoutx=1s"}, 0},
+		{[]string{"test.timeout=1s"}, 0},
+	}
+	for _, tt := range tests {
+		if got := testTimeout(tt.args); got != tt.want {
+			t.Errorf("testTimeout(%q) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	if apeLoader() != "" {
+		t.Skip("ape loader installed; it does not run shell scripts")
+	}
+	// Without an ape loader the binary is handed to /bin/sh,
+	// so a shell script stands in for it.
+	bin := filepath.Join(t.TempDir(), "exit.com")
+	if err := os.WriteFile(bin, []byte("exit \"$1\"\n"), 0777); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []int{0, 1, 3} {
+		got, err := runMain(bin, []string{strconv.Itoa(want)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("exit code = %d, want %d", got, want)
+		}
+	}
+}
+
+func TestIsELF(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		data string
+		want bool
+	}{
+		{"\x7fELF\x02\x01\x01", true},
+		{"MZqFpD='\n", false},
+		{"jartsr='\n", false},
+		{"\x7fEL", false},
+	}
+	for i, tt := range tests {
+		bin := filepath.Join(dir, strconv.Itoa(i))
+		if err := os.WriteFile(bin, []byte(tt.data), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if got := isELF(bin); got != tt.want {
+			t.Errorf("isELF(%q) = %v, want %v", tt.data, got, tt.want)
+		}
+	}
+	if isELF(filepath.Join(dir, "missing")) {
+		t.Errorf("isELF of a missing file = true, want false")
+	}
+}
@@ -2,7 +2,7 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-//go:build aix || linux || dragonfly || openbsd || solaris
+//go:build aix || cosmo || linux || dragonfly || openbsd || solaris
 
 package tar
 
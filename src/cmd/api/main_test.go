@@ -175,6 +175,8 @@ func Check(t *testing.T) {
 	for _, file := range nextFiles {
 		required = append(required, fileFeatures(file, true)...)
 	}
+	// The cosmo port's additions have no upstream proposal to cite.
+	required = append(required, fileFeatures(filepath.Join(testenv.GOROOT(t), "api/cosmo.txt"), false)...)
 	exception := fileFeatures(filepath.Join(testenv.GOROOT(t), "api/except.txt"), false)
 
 	if exitCode == 1 {
@@ -2,7 +2,7 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+//go:build aix || cosmo || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
 
 #include <pthread.h>
 #include "_cgo_export.h"
@@ -2,7 +2,7 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-//go:build windows || static || (darwin && internal)
+//go:build windows || cosmo || static || (darwin && internal)
 
 package cgotest
 
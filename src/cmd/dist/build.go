@@ -42,6 +42,7 @@ var (
 	gomips64         string
 	goppc64          string
 	goriscv64        string
+	gocosmo          string
 	goroot           string
 	goextlinkenabled string
 	gogcflags        string // For running built compiler
@@ -178,6 +179,12 @@ func xinit() {
 	}
 	goriscv64 = b
 
+	b = os.Getenv("GOCOSMO")
+	if b == "" {
+		b = "linux,freebsd,openbsd,netbsd,darwin,windows"
+	}
+	gocosmo = b
+
 	b = os.Getenv("GOFIPS140")
 	if b == "" {
 		b = "off"
@@ -245,6 +252,7 @@ func xinit() {
 	os.Setenv("GOMIPS64", gomips64)
 	os.Setenv("GOPPC64", goppc64)
 	os.Setenv("GORISCV64", goriscv64)
+	os.Setenv("GOCOSMO", gocosmo)
 	os.Setenv("GOROOT", goroot)
 	os.Setenv("GOFIPS140", gofips140)
 
@@ -327,6 +335,14 @@ var clangos = []string{
 	"openbsd", // OpenBSD ships with GCC 4.2, which is now quite old.
 }
 
+// cosmocc maps the cosmo architectures to the prefix of the cosmocc
+// toolchain drivers that compile for that architecture alone.
+// Plain cosmocc builds fat objects, but cgo needs one per GOARCH.
+var cosmocc = map[string]string{
+	"amd64": "x86_64-unknown-cosmo",
+	"arm64": "aarch64-unknown-cosmo",
+}
+
 // compilerEnvLookup returns the compiler settings for goos/goarch in map m.
 // kind is "CC" or "CXX".
 func compilerEnvLookup(kind string, m map[string]string, goos, goarch string) string {
@@ -339,6 +355,12 @@ func compilerEnvLookup(kind string, m map[string]string, goos, goarch string) st
 	if cc := m[""]; cc != "" {
 		return cc
 	}
+	if prefix := cosmocc[goarch]; goos == "cosmo" && prefix != "" {
+		if kind == "CXX" {
+			return prefix + "-c++"
+		}
+		return prefix + "-cc"
+	}
 	for _, os := range clangos {
 		if goos == os {
 			if kind == "CXX" {
@@ -640,6 +662,10 @@ func mustLinkExternal(goos, goarch string, cgoEnabled bool) bool {
 			// set up by the dynamic linker, so internal cgo linking
 			// doesn't work. Test case is "go test runtime/cgo".
 			return true
+		case "cosmo":
+			// APE programs are static, and the C library has to
+			// come from the cosmocc toolchain.
+			return true
 		}
 	}
 
@@ -1211,6 +1237,7 @@ func clean() {
 				xremove(exe)
 			}
 			xremove(exe + ".exe")
+			xremove(exe + ".com")
 		case !d.IsDir() && strings.HasPrefix(d.Name(), "z"):
 			// Remove generated file, identified by marker string.
 			head := make([]byte, 512)
@@ -1300,6 +1327,9 @@ func cmdenv() {
 	if goarch == "riscv64" {
 		xprintf(format, "GORISCV64", goriscv64)
 	}
+	if goos == "cosmo" {
+		xprintf(format, "GOCOSMO", gocosmo)
+	}
 	xprintf(format, "GOWORK", "off")
 
 	if *path {
@@ -1653,7 +1683,7 @@ func cmdbootstrap() {
 		if gohostos == "darwin" && filepath.Base(f) == ".DS_Store" {
 			continue // unfortunate but not unexpected
 		}
-		elem := strings.TrimSuffix(filepath.Base(f), ".exe")
+		elem := strings.TrimSuffix(filepath.Base(f), exe)
 		if !ok[f] && elem != "go" && elem != "gofmt" && elem != goos+"_"+goarch {
 			fatalf("unexpected new file in $GOROOT/bin: %s", elem)
 		}
@@ -1667,12 +1697,23 @@ func cmdbootstrap() {
 		xremove(pathf("%s/go_android_exec-adb-sync-status", os.TempDir()))
 	}
 
-	if wrapperPath := wrapperPathFor(goos, goarch); wrapperPath != "" {
+	// The go command targets cosmo on its own architecture unless GOOS
+	// says otherwise (see mkbuildcfg), so that target needs its wrapper
+	// even when the toolchain was built for another GOOS.
+	wrapperTargets := [][2]string{{goos, goarch}}
+	if goos != "cosmo" && cosmocc[goarch] != "" {
+		wrapperTargets = append(wrapperTargets, [2]string{"cosmo", goarch})
+	}
+	for _, target := range wrapperTargets {
+		wrapperPath := wrapperPathFor(target[0], target[1])
+		if wrapperPath == "" {
+			continue
+		}
 		oldcc := os.Getenv("CC")
 		os.Setenv("GOOS", gohostos)
 		os.Setenv("GOARCH", gohostarch)
 		os.Setenv("CC", compilerEnvLookup("CC", defaultcc, gohostos, gohostarch))
-		goCmd(nil, gorootBinGo, "build", "-o", pathf("%s/go_%s_%s_exec%s", gorootBin, goos, goarch, exe), wrapperPath)
+		goCmd(nil, gorootBinGo, "build", "-o", pathf("%s/go_%s_%s_exec%s", gorootBin, target[0], target[1], exe), wrapperPath)
 		// Restore environment.
 		// TODO(elias.naur): support environment variables in goCmd?
 		os.Setenv("GOOS", goos)
@@ -1701,6 +1742,11 @@ func wrapperPathFor(goos, goarch string) string {
 		if gohostos != "ios" {
 			return pathf("%s/misc/ios/go_ios_exec.go", goroot)
 		}
+	case goos == "cosmo":
+		// The wrapper runs APE binaries through a Unix shell.
+		if gohostos != "windows" && gohostos != "plan9" {
+			return pathf("%s/misc/cosmo/go_cosmo_exec.go", goroot)
+		}
 	}
 	return ""
 }
@@ -1798,6 +1844,8 @@ var cgoEnabled = map[string]bool{
 	"ios/amd64":       true,
 	"js/wasm":         false,
 	"wasip1/wasm":     false,
+	"cosmo/amd64":     true,
+	"cosmo/arm64":     true,
 	"netbsd/386":      true,
 	"netbsd/amd64":    true,
 	"netbsd/arm":      true,
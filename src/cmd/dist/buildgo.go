@@ -85,9 +85,9 @@ func defaultCCFunc(name string, defaultcc map[string]string) string {
 	if cc := defaultcc[""]; cc != "" {
 		fmt.Fprintf(&buf, "\treturn %s\n", quote(cc))
 	} else {
-		clang, gcc := "clang", "gcc"
+		clang, gcc, cosmoSuffix := "clang", "gcc", "-cc"
 		if strings.HasSuffix(name, "CXX") {
-			clang, gcc = "clang++", "g++"
+			clang, gcc, cosmoSuffix = "clang++", "g++", "-c++"
 		}
 		fmt.Fprintf(&buf, "\tswitch goos {\n")
 		fmt.Fprintf(&buf, "\tcase ")
@@ -99,6 +99,18 @@ func defaultCCFunc(name string, defaultcc map[string]string) string {
 		}
 		fmt.Fprintf(&buf, ":\n")
 		fmt.Fprintf(&buf, "\t\treturn %s\n", quote(clang))
+		fmt.Fprintf(&buf, "\tcase %s:\n", quote("cosmo"))
+		fmt.Fprintf(&buf, "\t\tswitch goarch {\n")
+		var arches []string
+		for arch := range cosmocc {
+			arches = append(arches, arch)
+		}
+		sort.Strings(arches)
+		for _, arch := range arches {
+			fmt.Fprintf(&buf, "\t\tcase %s:\n", quote(arch))
+			fmt.Fprintf(&buf, "\t\t\treturn %s\n", quote(cosmocc[arch]+cosmoSuffix))
+		}
+		fmt.Fprintf(&buf, "\t\t}\n")
 		fmt.Fprintf(&buf, "\t}\n")
 		fmt.Fprintf(&buf, "\treturn %s\n", quote(gcc))
 	}
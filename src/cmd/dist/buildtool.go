@@ -81,6 +81,7 @@ var bootstrapDirs = []string{
 	"internal/goroot",
 	"internal/gover",
 	"internal/goversion",
+	"internal/hostos",
 	// internal/lazyregexp is provided by Go 1.17, which permits it to
 	// be imported by other packages in this list, but is not provided
 	// by the Go 1.17 version of gccgo. It's on this list only to
@@ -7,6 +7,7 @@ package main
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -576,11 +577,33 @@ func (t *tester) registerStdTest(pkg string) {
 				break
 			}
 		}
-		return (&goTest{
-			timeout: timeoutSec,
-			gcflags: gcflags,
-			pkgs:    stdMatches,
-		}).run(t)
+		// Packages with known failures on this platform run on their
+		// own, so that the -skip pattern applies to them alone.
+		var pkgs, withSkips []string
+		for _, pkg := range stdMatches {
+			if knownFailures(pkg) != "" {
+				withSkips = append(withSkips, pkg)
+			} else {
+				pkgs = append(pkgs, pkg)
+			}
+		}
+		var errs []error
+		if len(pkgs) > 0 {
+			errs = append(errs, (&goTest{
+				timeout: timeoutSec,
+				gcflags: gcflags,
+				pkgs:    pkgs,
+			}).run(t))
+		}
+		for _, pkg := range withSkips {
+			errs = append(errs, (&goTest{
+				timeout: timeoutSec,
+				gcflags: gcflags,
+				skip:    knownFailures(pkg),
+				pkg:     pkg,
+			}).run(t))
+		}
+		return errors.Join(errs...)
 	})
 }
 
@@ -1226,7 +1249,8 @@ func (t *tester) internalLinkPIE() bool {
 		return false
 	}
 	switch goos + "-" + goarch {
-	case "darwin-amd64", "darwin-arm64",
+	case "cosmo-amd64", "cosmo-arm64",
+		"darwin-amd64", "darwin-arm64",
 		"linux-amd64", "linux-arm64", "linux-loong64", "linux-ppc64le",
 		"android-arm64",
 		"windows-amd64", "windows-386", "windows-arm64":
@@ -1719,7 +1743,7 @@ func raceDetectorSupported(goos, goarch string) bool {
 		return goarch == "amd64" || goarch == "arm64" || goarch == "loong64" || goarch == "ppc64le" || goarch == "riscv64" || goarch == "s390x"
 	case "darwin":
 		return goarch == "amd64" || goarch == "arm64"
-	case "freebsd", "netbsd", "windows":
+	case "cosmo", "freebsd", "netbsd", "windows":
 		return goarch == "amd64"
 	default:
 		return false
@@ -1759,6 +1783,8 @@ func buildModeSupported(compiler, buildmode, goos, goarch string) bool {
 				// in a PIE or shared library.
 				return false
 			}
+		case "cosmo":
+			return goarch == "amd64" || goarch == "arm64"
 		case "freebsd":
 			return goarch == "amd64"
 		}
@@ -1786,6 +1812,7 @@ func buildModeSupported(compiler, buildmode, goos, goarch string) bool {
 		switch platform {
 		case "linux/386", "linux/amd64", "linux/arm", "linux/arm64", "linux/loong64", "linux/ppc64le", "linux/riscv64", "linux/s390x",
 			"android/amd64", "android/arm", "android/arm64", "android/386",
+			"cosmo/amd64", "cosmo/arm64",
 			"freebsd/amd64",
 			"darwin/amd64", "darwin/arm64",
 			"ios/amd64", "ios/arm64",
@@ -0,0 +1,134 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// cosmoKnownFailures lists the std and cmd tests that are known to fail
+// for GOOS=cosmo on a Linux host, by package. dist test skips them so
+// that new failures in the port show up per package. A name may be a
+// subtest, as in "TestScript/name".
+//
+// Remove entries as the tests are fixed; add new ones only with a note
+// on why they fail.
+var cosmoKnownFailures = map[string][]string{
+	// cmd/internal/objfile and debug/dwarf find no symbols or DWARF in
+	// an APE binary, so the tests that inspect the binaries they build
+	// fail.
+	"cmd/addr2line": {
+		"TestAddr2Line",
+	},
+	"cmd/compile/internal/dwarfgen": {
+		"TestEmptyDwarfRanges",
+		"TestIssue75249",
+		"TestScopeRanges",
+	},
+	"cmd/compile/internal/ssa": {
+		"TestStmtLines",
+	},
+	"cmd/link/internal/ld": {
+		"TestAbstractOriginSanityIssue25459",
+		"TestAbstractOriginSanityIssue26237",
+		"TestDictIndex",
+		"TestEmbeddedStructMarker",
+		"TestFieldOverlap",
+		"TestInlinedRoutineArgsVars",
+		"TestInlinedRoutineCallFileLine",
+		"TestIssue27614",
+		"TestIssue38192",
+		"TestIssue39757",
+		"TestIssue42484",
+		"TestIssue54320",
+		"TestOptimizedOutParamHandling",
+		"TestOutputParamAbbrevAndAttr",
+		"TestPackageNameAttr",
+		"TestRuntimeTypeAttrInternal",
+		"TestRuntimeTypesPresent",
+		"TestSizes",
+		"TestStaticTmp",
+		"TestSubprogramDeclFileLine",
+		"TestVarDeclLine",
+	},
+	"cmd/nm": {
+		"TestGoExec",
+	},
+	"cmd/objdump": {
+		"TestDisasm",
+		"TestDisasmCode",
+		"TestDisasmGnuAsm",
+	},
+	"cmd/pprof": {
+		"TestDisasm",
+	},
+
+	// The go command finds no build ID or build info in an installed
+	// APE binary, os.Args[0] names the copy that the APE launcher
+	// extracts to $TMPDIR, and the launcher needs uname, which is not
+	// on the empty PATH that some scripts run with. The module proxy
+	// has no cosmo toolchains.
+	"cmd/go": {
+		"TestScript/gotoolchain_issue66175",
+		"TestScript/gotoolchain_net",
+		"TestScript/install_rebuild_removed",
+		"TestScript/mod_doc_path",
+		"TestScript/mod_install_pkg_version",
+		"TestScript/mod_nomod",
+		"TestScript/tooltags",
+	},
+
+	// The tests hand the test binary to the go command as -toolexec.
+	// The go command execs the binary directly, which fails with
+	// ENOEXEC.
+	"cmd/compile/internal/test": {
+		"TestInst",
+		"TestPGOHash",
+		"TestScanfRemoval",
+	},
+	"cmd/cover": {
+		"TestCoverWithToolExec",
+	},
+	"cmd/link": {
+		"TestCheckLinkname",
+		"TestContentAddressableSymbols",
+		"TestFlagS",
+		"TestFlagW",
+		"TestFuncAlignOption",
+		"TestFuncdataPlacement",
+		"TestIssue38554",
+		"TestIssue42396",
+		"TestLargeReloc",
+		"TestLinknameBSS",
+		"TestMachOBuildVersion",
+		"TestModuledataPlacement",
+		"TestRandLayout",
+		"TestScript/randlayout_option", // go tool nm finds no symbols
+		"TestStrictDup",
+		"TestUnresolved",
+		"TestXFlag",
+	},
+}
+
+// knownFailures returns the go test -skip pattern for the tests in pkg
+// that are known to fail on the target platform, or "" if there are none.
+func knownFailures(pkg string) string {
+	if goos != "cosmo" {
+		return ""
+	}
+	var alts []string
+	for _, name := range cosmoKnownFailures[pkg] {
+		// -skip splits the pattern at each slash and matches the
+		// elements against the levels of the test name. Anchor
+		// each element so that only the named test matches.
+		var elems []string
+		for _, elem := range strings.Split(name, "/") {
+			elems = append(elems, "^"+regexp.QuoteMeta(elem)+"$")
+		}
+		alts = append(alts, strings.Join(elems, "/"))
+	}
+	return strings.Join(alts, "|")
+}
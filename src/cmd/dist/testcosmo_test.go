@@ -0,0 +1,91 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestCosmoKnownFailures checks that the cosmo skip list is sorted and
+// only names tests or their direct subtests.
+func TestCosmoKnownFailures(t *testing.T) {
+	testName := regexp.MustCompile(`^(Test|Benchmark|Example|Fuzz)\w*(/[^/|]+)?$`)
+	for pkg, names := range cosmoKnownFailures {
+		if len(names) == 0 {
+			t.Errorf("%s: empty list", pkg)
+		}
+		if !slices.IsSorted(names) {
+			t.Errorf("%s: tests are not sorted: %q", pkg, names)
+		}
+		if len(slices.Compact(slices.Clone(names))) != len(names) {
+			t.Errorf("%s: duplicate tests: %q", pkg, names)
+		}
+		for _, name := range names {
+			if !testName.MatchString(name) {
+				t.Errorf("%s: %q is not a test or subtest name", pkg, name)
+			}
+		}
+	}
+}
+
+func TestKnownFailures(t *testing.T) {
+	defer func(old string) { goos = old }(goos)
+
+	goos = "linux"
+	if skip := knownFailures("net"); skip != "" {
+		t.Errorf("knownFailures(%q) on linux = %q; want \"\"", "net", skip)
+	}
+
+	goos = "cosmo"
+	for pkg, names := range cosmoKnownFailures {
+		skip := knownFailures(pkg)
+		for _, name := range names {
+			if !skipMatches(t, skip, name) {
+				t.Errorf("knownFailures(%q) = %q does not match %q", pkg, skip, name)
+			}
+			if skipMatches(t, skip, name+"X") {
+				t.Errorf("knownFailures(%q) = %q matches %q", pkg, skip, name+"X")
+			}
+		}
+	}
+	if got, want := knownFailures("cmd/nm"), "^TestGoExec$"; got != want {
+		t.Errorf("knownFailures(%q) = %q; want %q", "cmd/nm", got, want)
+	}
+	if skip := knownFailures("strings"); skip != "" {
+		t.Errorf("knownFailures(%q) = %q; want \"\"", "strings", skip)
+	}
+}
+
+// skipMatches reports whether the -skip pattern skip matches the test
+// name, splitting both at slashes the way the testing package does.
+// The patterns built by knownFailures have no slashes or bars inside
+// parentheses, so a plain split is enough here.
+func skipMatches(t *testing.T, skip, name string) bool {
+	levels := strings.Split(name, "/")
+	for _, alt := range strings.Split(skip, "|") {
+		elems := strings.Split(alt, "/")
+		if len(elems) > len(levels) {
+			continue
+		}
+		matched := true
+		for i, elem := range elems {
+			rx, err := regexp.Compile(elem)
+			if err != nil {
+				t.Fatalf("bad -skip element %q: %v", elem, err)
+			}
+			if !rx.MatchString(levels[i]) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
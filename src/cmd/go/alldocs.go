@@ -1783,10 +1783,10 @@
 //
 // If the -exec flag is not given, GOOS or GOARCH is different from the system
 // default, and a program named go_$GOOS_$GOARCH_exec can be found
-// on the current search path, 'go run' invokes the binary using that program,
-// for example 'go_js_wasm_exec a.out arguments...'. This allows execution of
-// cross-compiled programs when a simulator or other execution method is
-// available.
+// on the current search path or in $GOROOT/bin, 'go run' invokes the
+// binary using that program, for example 'go_js_wasm_exec a.out arguments...'.
+// This allows execution of cross-compiled programs when a simulator or
+// other execution method is available.
 //
 // By default, 'go run' compiles the binary without generating the information
 // used by debuggers, to reduce build time. To include debugger information in
@@ -2160,6 +2160,11 @@
 //   - For GOARCH=wasm, GOWASM=satconv and signext
 //     correspond to the wasm.satconv and wasm.signext feature build tags.
 //
+// The defined operating system feature build tags are:
+//
+//   - For GOOS=cosmo, each host operating system listed in GOCOSMO
+//     sets a feature build tag, such as cosmo.linux or cosmo.windows.
+//
 // For GOARCH=amd64, arm, ppc64, ppc64le, and riscv64, a particular feature level
 // sets the feature build tags for all previous levels as well.
 // For example, GOAMD64=v2 sets the amd64.v1 and amd64.v2 feature flags.
@@ -2545,6 +2550,15 @@
 //		For GOARCH=wasm, comma-separated list of experimental WebAssembly features to use.
 //		Valid values are satconv, signext.
 //
+// Operating system-specific environment variables:
+//
+//	GOCOSMO
+//		For GOOS=cosmo, comma-separated list of the host operating systems
+//		the executable must run on. Valid values are linux, freebsd, openbsd,
+//		netbsd, darwin and windows. The default is all of them.
+//		The linker leaves out the parts of the executable header
+//		that only the hosts not listed would use.
+//
 // Environment variables for use with code coverage:
 //
 //	GOCOVERDIR
@@ -2582,7 +2596,8 @@
 // Additional information available from 'go env' but not read from the environment:
 //
 //	GOEXE
-//		The executable file name suffix (".exe" on Windows, "" on other systems).
+//		The executable file name suffix (".exe" on Windows, ".com" on cosmo,
+//		"" on other systems).
 //	GOGCCFLAGS
 //		A space-separated list of arguments supplied to the CC command.
 //	GOHOSTARCH
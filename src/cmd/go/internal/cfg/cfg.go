@@ -41,8 +41,18 @@ var (
 )
 
 func exeSuffix() string {
-	if Goos == "windows" {
+	return goosExeSuffix(Goos)
+}
+
+// goosExeSuffix returns the executable file name suffix for goos.
+// Cosmopolitan executables are conventionally named with a .com
+// suffix, which is also what lets Windows run them.
+func goosExeSuffix(goos string) string {
+	switch goos {
+	case "windows":
 		return ".exe"
+	case "cosmo":
+		return ".com"
 	}
 	return ""
 }
@@ -60,10 +70,7 @@ var (
 // ToolExeSuffix returns the suffix for executables installed
 // in build.ToolDir.
 func ToolExeSuffix() string {
-	if installedGOOS == "windows" {
-		return ".exe"
-	}
-	return ""
+	return goosExeSuffix(installedGOOS)
 }
 
 // These are general "build flags" used by build and other commands.
@@ -223,6 +230,7 @@ func ForceHost() {
 	GOPPC64 = buildcfg.DefaultGOPPC64
 	GORISCV64 = buildcfg.DefaultGORISCV64
 	GOWASM = ""
+	GOCOSMO = buildcfg.DefaultGOCOSMO
 
 	// Recompute the build context using Goos and Goarch to
 	// set the correct value for ctx.CgoEnabled.
@@ -474,6 +482,7 @@ var (
 	GOPPC64, goPPC64Changed     = EnvOrAndChanged("GOPPC64", buildcfg.DefaultGOPPC64)
 	GORISCV64, goRISCV64Changed = EnvOrAndChanged("GORISCV64", buildcfg.DefaultGORISCV64)
 	GOWASM, goWASMChanged       = EnvOrAndChanged("GOWASM", fmt.Sprint(buildcfg.GOWASM))
+	GOCOSMO, goCOSMOChanged     = EnvOrAndChanged("GOCOSMO", buildcfg.DefaultGOCOSMO)
 
 	GOFIPS140, GOFIPS140Changed = EnvOrAndChanged("GOFIPS140", buildcfg.DefaultGOFIPS140)
 	GOPROXY, GOPROXYChanged     = EnvOrAndChanged("GOPROXY", "")
@@ -532,6 +541,18 @@ func GetArchEnv() (key, val string, changed bool) {
 	return "", "", false
 }
 
+// GetOSEnv returns the name and setting of the
+// GOOS-specific operating system environment variable.
+// If the current operating system has no GOOS-specific variable,
+// GetOSEnv returns empty key and value.
+func GetOSEnv() (key, val string, changed bool) {
+	switch Goos {
+	case "cosmo":
+		return "GOCOSMO", GOCOSMO, goCOSMOChanged
+	}
+	return "", "", false
+}
+
 // envOr returns Getenv(key) if set, or else def.
 func envOr(key, def string) string {
 	val := Getenv(key)
@@ -373,6 +373,15 @@ func clean(p *load.Package) {
 		}
 	}
 
+	// Cosmopolitan executables are named with a .com suffix.
+	if cfg.ExeSuffix == ".com" {
+		for _, name := range allRemove {
+			if !strings.HasSuffix(name, ".exe") {
+				allRemove = append(allRemove, name+".com")
+			}
+		}
+	}
+
 	if cfg.BuildN || cfg.BuildX {
 		sh.ShowCmd(p.Dir, "rm -f %s", strings.Join(allRemove, " "))
 	}
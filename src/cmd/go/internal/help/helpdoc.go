@@ -675,6 +675,15 @@ Architecture-specific environment variables:
 		For GOARCH=wasm, comma-separated list of experimental WebAssembly features to use.
 		Valid values are satconv, signext.
 
+Operating system-specific environment variables:
+
+	GOCOSMO
+		For GOOS=cosmo, comma-separated list of the host operating systems
+		the executable must run on. Valid values are linux, freebsd, openbsd,
+		netbsd, darwin and windows. The default is all of them.
+		The linker leaves out the parts of the executable header
+		that only the hosts not listed would use.
+
 Environment variables for use with code coverage:
 
 	GOCOVERDIR
@@ -712,7 +721,8 @@ Special-purpose environment variables:
 Additional information available from 'go env' but not read from the environment:
 
 	GOEXE
-		The executable file name suffix (".exe" on Windows, "" on other systems).
+		The executable file name suffix (".exe" on Windows, ".com" on cosmo,
+		"" on other systems).
 	GOGCCFLAGS
 		A space-separated list of arguments supplied to the CC command.
 	GOHOSTARCH
@@ -979,6 +989,11 @@ The defined architecture feature build tags are:
 	- For GOARCH=wasm, GOWASM=satconv and signext
 	  correspond to the wasm.satconv and wasm.signext feature build tags.
 
+The defined operating system feature build tags are:
+
+	- For GOOS=cosmo, each host operating system listed in GOCOSMO
+	  sets a feature build tag, such as cosmo.linux or cosmo.windows.
+
 For GOARCH=amd64, arm, ppc64, ppc64le, and riscv64, a particular feature level
 sets the feature build tags for all previous levels as well.
 For example, GOAMD64=v2 sets the amd64.v1 and amd64.v2 feature flags.
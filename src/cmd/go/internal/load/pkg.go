@@ -418,6 +418,14 @@ func (p *Package) copyBuild(opts PackageOpts, pp *build.Package) {
 	p.SwigFiles = pp.SwigFiles
 	p.SwigCXXFiles = pp.SwigCXXFiles
 	p.SysoFiles = pp.SysoFiles
+	if cfg.Goos == "cosmo" && p.Standard && str.HasPathPrefix(p.ImportPath, "runtime/race") {
+		// The cosmo race detector runtime is the Linux one,
+		// but race_linux.syso doesn't match GOOS=cosmo.
+		if i := slices.Index(p.IgnoredOtherFiles, "race_linux.syso"); i >= 0 {
+			p.IgnoredOtherFiles = slices.Delete(slices.Clone(p.IgnoredOtherFiles), i, i+1)
+			p.SysoFiles = append(slices.Clip(p.SysoFiles), "race_linux.syso")
+		}
+	}
 	if cfg.BuildMSan {
 		// There's no way for .syso files to be built both with and without
 		// support for memory sanitizer. Assume they are built without,
@@ -2481,6 +2489,9 @@ func (p *Package) setBuildInfo(ctx context.Context, f *modfetch.Fetcher, autoVCS
 	if key, val, _ := cfg.GetArchEnv(); key != "" && val != "" {
 		appendSetting(key, val)
 	}
+	if key, val, _ := cfg.GetOSEnv(); key != "" && val != "" {
+		appendSetting(key, val)
+	}
 
 	// Add VCS status if all conditions are true:
 	//
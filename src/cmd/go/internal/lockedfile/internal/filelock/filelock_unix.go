@@ -2,7 +2,7 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-//go:build darwin || dragonfly || freebsd || illumos || linux || netbsd || openbsd
+//go:build cosmo || darwin || dragonfly || freebsd || illumos || linux || netbsd || openbsd
 
 package filelock
 
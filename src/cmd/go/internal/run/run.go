@@ -44,10 +44,10 @@ If the -exec flag is given, 'go run' invokes the binary using xprog:
 	'xprog a.out arguments...'.
 If the -exec flag is not given, GOOS or GOARCH is different from the system
 default, and a program named go_$GOOS_$GOARCH_exec can be found
-on the current search path, 'go run' invokes the binary using that program,
-for example 'go_js_wasm_exec a.out arguments...'. This allows execution of
-cross-compiled programs when a simulator or other execution method is
-available.
+on the current search path or in $GOROOT/bin, 'go run' invokes the
+binary using that program, for example 'go_js_wasm_exec a.out arguments...'.
+This allows execution of cross-compiled programs when a simulator or
+other execution method is available.
 
 By default, 'go run' compiles the binary without generating the information
 used by debuggers, to reduce build time. To include debugger information in
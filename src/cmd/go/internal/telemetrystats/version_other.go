@@ -2,7 +2,7 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-//go:build !cmd_go_bootstrap && !unix && !windows
+//go:build !cmd_go_bootstrap && ((!unix && !windows) || cosmo)
 
 package telemetrystats
 
@@ -52,6 +52,7 @@ var passAnalyzersToVet = map[string]bool{
 	"cgocall":          true,
 	"composites":       true,
 	"copylocks":        true,
+	"cosmo":            true,
 	"defers":           true,
 	"directive":        true,
 	"errorsas":         true,
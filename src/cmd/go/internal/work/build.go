@@ -904,7 +904,13 @@ func FindExecCmd() []string {
 	if cfg.Goos == runtime.GOOS && cfg.Goarch == runtime.GOARCH {
 		return ExecCmd
 	}
-	path, err := pathcache.LookPath(fmt.Sprintf("go_%s_%s_exec", cfg.Goos, cfg.Goarch))
+	name := fmt.Sprintf("go_%s_%s_exec", cfg.Goos, cfg.Goarch)
+	path, err := pathcache.LookPath(name)
+	if err != nil && cfg.GOROOTbin != "" {
+		// make.bash installs the wrappers it builds in $GOROOT/bin,
+		// which need not be on the search path.
+		path, err = pathcache.LookPath(filepath.Join(cfg.GOROOTbin, name))
+	}
 	if err == nil {
 		ExecCmd = []string{path}
 	}
@@ -365,6 +365,11 @@ func (b *Builder) buildActionID(a *Action) cache.ActionID {
 		key, val, _ := cfg.GetArchEnv()
 		fmt.Fprintf(h, "%s=%s\n", key, val)
 
+		// GOCOSMO.
+		if key, val, _ := cfg.GetOSEnv(); key != "" {
+			fmt.Fprintf(h, "%s=%s\n", key, val)
+		}
+
 		if cfg.CleanGOEXPERIMENT != "" {
 			fmt.Fprintf(h, "GOEXPERIMENT=%q\n", cfg.CleanGOEXPERIMENT)
 		}
@@ -1556,6 +1561,11 @@ func (b *Builder) printLinkerConfig(h io.Writer, p *load.Package) {
 		key, val, _ := cfg.GetArchEnv()
 		fmt.Fprintf(h, "%s=%s\n", key, val)
 
+		// GOCOSMO.
+		if key, val, _ := cfg.GetOSEnv(); key != "" {
+			fmt.Fprintf(h, "%s=%s\n", key, val)
+		}
+
 		if cfg.CleanGOEXPERIMENT != "" {
 			fmt.Fprintf(h, "GOEXPERIMENT=%q\n", cfg.CleanGOEXPERIMENT)
 		}
@@ -2121,6 +2131,8 @@ var objectMagic = [][]byte{
 	{0x00, 0x61, 0x73, 0x6D},                  // WASM
 	{0x01, 0xDF},                              // XCOFF 32bit
 	{0x01, 0xF7},                              // XCOFF 64bit
+	{'M', 'Z', 'q', 'F', 'p', 'D', '=', '\''}, // APE (cosmo)
+	{'j', 'a', 'r', 't', 's', 'r', '=', '\''}, // APE without a PE header (cosmo)
 }
 
 func isObject(s string) bool {
@@ -3219,6 +3231,13 @@ func (b *Builder) dynimport(a *Action, objdir, importGo, cgoExe string, cflags,
 	p := a.Package
 	sh := b.Shell(a)
 
+	if cfg.Goos == "cosmo" {
+		// The imports are only needed for internal linking, which
+		// cosmo never uses for cgo. The APE programs that cosmocc
+		// links are static and have no imports to find anyway.
+		return "", "", nil
+	}
+
 	cfile := objdir + "_cgo_main.c"
 	ofile := objdir + "_cgo_main.o"
 	if err := b.gcc(a, objdir, ofile, cflags, cfile); err != nil {
@@ -3629,7 +3648,7 @@ func useResponseFile(path string, argLen int) bool {
 	// Unless the program uses objabi.Flagparse, which understands
 	// response files, don't use response files.
 	// TODO: Note that other toolchains like CC are missing here for now.
-	prog := strings.TrimSuffix(filepath.Base(path), ".exe")
+	prog := strings.TrimSuffix(filepath.Base(path), cfg.ToolExeSuffix())
 	switch prog {
 	case "compile", "link", "cgo", "asm", "cover":
 	default:
@@ -14,6 +14,7 @@ import (
 	"cmd/go/internal/modload"
 	"cmd/internal/quoted"
 	"fmt"
+	"internal/buildcfg"
 	"internal/platform"
 	"os"
 	"os/exec"
@@ -47,6 +48,9 @@ func makeCfgChangedEnv() []string {
 	if archenv, val, changed := cfg.GetArchEnv(); changed {
 		env = append(env, archenv+"="+val)
 	}
+	if osenv, val, changed := cfg.GetOSEnv(); changed {
+		env = append(env, osenv+"="+val)
+	}
 	return slices.Clip(env)
 }
 
@@ -158,6 +162,15 @@ func instrumentInit() {
 		base.SetExitStatus(2)
 		base.Exit()
 	}
+	if cfg.BuildRace && cfg.Goos == "cosmo" {
+		// The race detector runtime is the Linux one, which makes
+		// Linux system calls directly.
+		if hosts, err := buildcfg.ParseGocosmo(cfg.GOCOSMO); err == nil && hosts.String() != "linux" {
+			fmt.Fprintf(os.Stderr, "-race requires GOCOSMO=linux on cosmo, have GOCOSMO=%s\n", cfg.GOCOSMO)
+			base.SetExitStatus(2)
+			base.Exit()
+		}
+	}
 	if cfg.BuildASan && !platform.ASanSupported(cfg.Goos, cfg.Goarch) {
 		fmt.Fprintf(os.Stderr, "-asan is not supported on %s/%s\n", cfg.Goos, cfg.Goarch)
 		base.SetExitStatus(2)
@@ -235,7 +248,7 @@ func buildModeInit() {
 					codegenArg = "-shared"
 				}
 
-			case "dragonfly", "freebsd", "illumos", "linux", "netbsd", "openbsd", "solaris":
+			case "cosmo", "dragonfly", "freebsd", "illumos", "linux", "netbsd", "openbsd", "solaris":
 				// Use -shared so that the result is
 				// suitable for inclusion in a PIE or
 				// shared library.
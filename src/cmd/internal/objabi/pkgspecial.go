@@ -67,6 +67,7 @@ var runtimePkgs = []string{
 	"internal/coverage/rtcov",
 	"internal/cpu",
 	"internal/goarch",
+	"internal/gocosmo",
 	"internal/godebugs",
 	"internal/goexperiment",
 	"internal/goos",
@@ -26,6 +26,9 @@ func HeaderString() string {
 	if k, v := buildcfg.GOGOARCH(); k != "" && v != "" {
 		archExtra = " " + k + "=" + v
 	}
+	if k, v := buildcfg.GOGOOS(); k != "" && v != "" {
+		archExtra += " " + k + "=" + v
+	}
 	return fmt.Sprintf("go object %s %s %s%s X:%s\n",
 		buildcfg.GOOS, buildcfg.GOARCH,
 		buildcfg.Version, archExtra,
@@ -97,14 +97,17 @@ var findToolOnce sync.Once
 
 // findToolSub sets toolsub to the value used by the current go command.
 func findToolSub(t *testing.T) {
-	gocmd := testenv.Command(t, testenv.GoToolPath(t), "env", "GOHOSTARCH")
+	gocmd := testenv.Command(t, testenv.GoToolPath(t), "env", "GOHOSTOS", "GOHOSTARCH")
 	gocmd = testenv.CleanCmdEnv(gocmd)
-	goHostArchBytes, err := gocmd.CombinedOutput()
+	goHostBytes, err := gocmd.CombinedOutput()
 	if err != nil {
-		t.Fatalf("%s failed: %v\n%s", gocmd, err, goHostArchBytes)
+		t.Fatalf("%s failed: %v\n%s", gocmd, err, goHostBytes)
 	}
-	goHostArch := strings.TrimSpace(string(goHostArchBytes))
-	toolsub = filepath.Join("pkg", "tool", runtime.GOOS+"_"+goHostArch)
+	goHost := strings.Fields(string(goHostBytes))
+	if len(goHost) != 2 {
+		t.Fatalf("%s: unexpected output %q", gocmd, goHostBytes)
+	}
+	toolsub = filepath.Join("pkg", "tool", goHost[0]+"_"+goHost[1])
 }
 
 // linkOrCopy creates a link to src at dst, or if the symlink fails
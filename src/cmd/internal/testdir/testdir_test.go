@@ -14,6 +14,7 @@ import (
 	"go/build"
 	"go/build/constraint"
 	"hash/fnv"
+	"internal/syslist"
 	"internal/testenv"
 	"io"
 	"io/fs"
@@ -427,6 +428,10 @@ func (ctxt *context) match(name string) bool {
 		return true
 	}
 
+	if name == "unix" && syslist.UnixOS[ctxt.GOOS] {
+		return true
+	}
+
 	if ctxt.noOptEnv && name == "gcflags_noopt" {
 		return true
 	}
@@ -1008,7 +1013,7 @@ func (t test) run() error {
 		if err != nil {
 			return err
 		}
-		cmd = []string{"./a.exe"}
+		cmd = append(findExecCmd(), "./a.exe")
 		out, err := runcmd(append(cmd, args...)...)
 		if err != nil {
 			return err
@@ -1039,7 +1044,8 @@ func (t test) run() error {
 			if err := linkFile(runcmd, exe, pkg, stdlibImportcfgFile(), nil); err != nil {
 				return err
 			}
-			out, err = runcmd(append([]string{exe}, args...)...)
+			cmd := append(findExecCmd(), exe)
+			out, err = runcmd(append(cmd, args...)...)
 		} else {
 			cmd := []string{goTool, "run", t.goGcflags()}
 			if *linkshared {
@@ -1122,15 +1128,25 @@ func (t test) run() error {
 }
 
 var findExecCmd = sync.OnceValue(func() (execCmd []string) {
-	if goos == runtime.GOOS && goarch == runtime.GOARCH {
+	// cosmo binaries start with a shell script that execve does not
+	// run even on a cosmo host, so they always need the wrapper.
+	if goos == runtime.GOOS && goarch == runtime.GOARCH && goos != "cosmo" {
 		return nil
 	}
-	if path, err := exec.LookPath(fmt.Sprintf("go_%s_%s_exec", goos, goarch)); err == nil {
+	name := fmt.Sprintf("go_%s_%s_exec", goos, goarch)
+	if path, err := exec.LookPath(name); err == nil {
+		execCmd = []string{path}
+	} else if path := filepath.Join(testenv.GOROOT(nil), "bin", name); isExecutable(path) {
 		execCmd = []string{path}
 	}
 	return execCmd
 })
 
+func isExecutable(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.Mode().IsRegular() && fi.Mode()&0111 != 0
+}
+
 // checkExpectedOutput compares the output from compiling and/or running with the contents
 // of the corresponding reference output file, if any (replace ".go" with ".out").
 // If they don't match, fail with an informative message.
@@ -1814,6 +1830,12 @@ func TestShouldTest(t *testing.T) {
 
 	// Test that //go:build tag match.
 	assert(shouldTest("//go:build go1.4", "linux", "amd64"))
+
+	// cosmo is a unix platform, but not linux.
+	assert(shouldTest("//go:build unix", "cosmo", "amd64"))
+	assert(shouldTest("// +build !windows", "cosmo", "amd64"))
+	assertNot(shouldTest("//go:build linux", "cosmo", "amd64"))
+	assertNot(shouldTest("//go:build unix", "windows", "amd64"))
 }
 
 // overlayDir makes a minimal-overhead copy of srcRoot in which new files may be added.
@@ -950,7 +950,7 @@ func archreloc(target *ld.Target, ldr *loader.Loader, syms *ld.ArchSyms, r loade
 			// We are linking the final executable, so we
 			// can optimize any TLS IE relocation to LE.
 
-			if !target.IsLinux() {
+			if !target.IsLinux() && !target.IsCosmo() {
 				ldr.Errorf(s, "TLS reloc on unsupported OS %v", target.HeadType)
 			}
 
@@ -106,6 +106,19 @@ func archinit(ctxt *ld.Link) {
 			*ld.FlagTextAddr = ld.Rnd(0x10000, *ld.FlagRound) + int64(ld.HEADR)
 		}
 
+	case objabi.Hcosmo: /* cosmopolitan */
+		ld.Elfinit(ctxt)
+		ld.HEADR = ld.ELFRESERVE
+		// APE aligns arm64 ELF segments on 16K, the largest page size
+		// in its support vector, and Cosmopolitan loads aarch64 programs
+		// at 0x000800000000, above the 4GB floor that Apple imposes.
+		if *ld.FlagRound == -1 {
+			*ld.FlagRound = 16384 // 16K page alignment
+		}
+		if *ld.FlagTextAddr == -1 {
+			*ld.FlagTextAddr = ld.Rnd(0x800000000, *ld.FlagRound) + int64(ld.HEADR)
+		}
+
 	case objabi.Hdarwin: /* apple MACH */
 		ld.HEADR = ld.INITIAL_MACHO_HEADR
 		if *ld.FlagRound == -1 {
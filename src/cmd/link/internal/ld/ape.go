@@ -10,7 +10,9 @@ import (
 	"cmd/internal/sys"
 	"encoding/binary"
 	"fmt"
+	"internal/buildcfg"
 	"os"
+	"strings"
 )
 
 // APE (Actually Portable Executable) format implementation
@@ -21,42 +23,53 @@ import (
 // - Linux: Uses embedded ELF header (encoded as octal in printf)
 // - macOS x86-64: Uses dd command to copy Mach-O header backward
 // - macOS ARM64: Uses embedded ELF header (with APE loader)
+//
+// GOCOSMO selects which of these hosts the binary supports; the parts
+// of the header that only other hosts use are left out.
 
 const (
 	// APE header must be page-aligned for ELF loading
 	// Using 64KB for Windows allocation granularity compatibility
 	apeHeaderSize = 65536
 
+	// APE magic: the first bytes of the file, which open a
+	// single-quoted shell string. Binaries that support Windows
+	// start with the DOS magic; the others use the Unix-only magic
+	// from the APE specification.
+	apeMagicPE   = "MZqFpD='"
+	apeMagicUnix = "jartsr='"
+
 	// Page sizes
 	pageSize4K  = 4096
 	pageSize16K = 16384
 
 	// ELF constants
-	elfMagic      = "\x7fELF"
-	elfClass64    = 2
-	elfDataLSB    = 1
+	elfMagic        = "\x7fELF"
+	elfClass64      = 2
+	elfDataLSB      = 1
 	elfOSABIFreeBSD = 9 // Use FreeBSD ABI per spec
-	elfTypeExec   = 2
+	elfTypeExec     = 2
+	elfTypeDyn      = 3
 	elfMachineAMD64 = 0x3E
 	elfMachineARM64 = 0xB7
 
 	// Mach-O constants
-	machoMagic64     = 0xFEEDFACF
-	machoCPUTypeX64  = 0x01000007
+	machoMagic64       = 0xFEEDFACF
+	machoCPUTypeX64    = 0x01000007
 	machoCPUSubtypeX64 = 0x80000003
-	machoFileTypeExec = 0x2
-	machoFlagNoUndefs = 0x1
-	machoFlagPIE      = 0x200000
+	machoFileTypeExec  = 0x2
+	machoFlagNoUndefs  = 0x1
+	machoFlagPIE       = 0x200000
 
 	// Load commands
-	machoLCSegment64   = 0x19
-	machoLCUnixThread  = 0x5
-	machoLCMain        = 0x80000028
+	machoLCSegment64  = 0x19
+	machoLCUnixThread = 0x5
+	machoLCMain       = 0x80000028
 
 	// Segment protection
-	machoProtRead    = 0x1
-	machoProtWrite   = 0x2
-	machoProtExec    = 0x4
+	machoProtRead  = 0x1
+	machoProtWrite = 0x2
+	machoProtExec  = 0x4
 )
 
 // convertToAPE converts an ELF binary to Actually Portable Executable format.
@@ -65,6 +78,12 @@ func (ctxt *Link) convertToAPE() {
 		return
 	}
 
+	// A c-archive is left as ELF objects; the C toolchain that links
+	// it into a program produces the APE.
+	if ctxt.BuildMode != BuildModeExe && ctxt.BuildMode != BuildModePIE {
+		return
+	}
+
 	outfile := *flagOutfile
 	if outfile == "" {
 		return
@@ -76,6 +95,12 @@ func (ctxt *Link) convertToAPE() {
 		Exitf("cannot read output file for APE conversion: %v", err)
 	}
 
+	// The x86-64 cosmocc driver writes an APE program itself when it
+	// links externally; only an ELF from other toolchains needs wrapping.
+	if ctxt.LinkMode == LinkExternal && isAPE(elfData) {
+		return
+	}
+
 	// Verify it's a valid ELF
 	if len(elfData) < 64 || string(elfData[0:4]) != elfMagic {
 		Exitf("output file is not a valid ELF binary")
@@ -94,7 +119,7 @@ func (ctxt *Link) convertToAPE() {
 	defer apeFile.Close()
 
 	// Build the APE header with embedded formats
-	header := makeAPEHeader(elfData, elfEntry, elfPhoff, elfPhnum, ctxt.Arch.Family)
+	header := makeAPEHeader(elfData, elfEntry, elfPhoff, elfPhnum, ctxt.Arch.Family, buildcfg.GOCOSMO)
 
 	if _, err := apeFile.Write(header); err != nil {
 		Exitf("cannot write APE header: %v", err)
@@ -111,13 +136,36 @@ func (ctxt *Link) convertToAPE() {
 	}
 }
 
+// isAPE reports whether data starts with one of the APE magics.
+func isAPE(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(apeMagicPE)) || bytes.HasPrefix(data, []byte(apeMagicUnix))
+}
+
+// apeHeaderLen returns the size of the APE header, which is also the
+// offset of the ELF payload in the file. Windows maps the image in 64KB
+// units, but without it a page is enough, or two for amd64 binaries
+// that carry a Mach-O header at 0x1000.
+func apeHeaderLen(arch sys.ArchFamily, hosts buildcfg.GocosmoHosts) int {
+	switch {
+	case hosts.Has("windows"):
+		return apeHeaderSize
+	case arch == sys.ARM64:
+		return pageSize16K
+	case hosts.Has("darwin"):
+		return 2 * pageSize4K
+	}
+	return pageSize4K
+}
+
 // makeAPEHeader creates an APE header following the specification.
 // The header is a polyglot containing:
 // - MZ/PE header for Windows
 // - Shell script with printf-encoded ELF header for Linux/BSD
 // - Mach-O header and dd command for macOS x86-64
-func makeAPEHeader(elfData []byte, elfEntry, elfPhoff uint64, elfPhnum uint16, arch sys.ArchFamily) []byte {
-	header := make([]byte, apeHeaderSize)
+// Each part is only present if hosts includes the systems that use it.
+func makeAPEHeader(elfData []byte, elfEntry, elfPhoff uint64, elfPhnum uint16, arch sys.ArchFamily, hosts buildcfg.GocosmoHosts) []byte {
+	headerLen := apeHeaderLen(arch, hosts)
+	header := make([]byte, headerLen)
 
 	// Determine page size based on architecture
 	pageSize := uint64(pageSize4K)
@@ -126,7 +174,7 @@ func makeAPEHeader(elfData []byte, elfEntry, elfPhoff uint64, elfPhnum uint16, a
 	}
 
 	// ELF payload starts after the APE header
-	elfOffset := uint64(apeHeaderSize)
+	elfOffset := uint64(headerLen)
 
 	// Calculate the actual entry point in the APE file
 	// The ELF entry point is relative to the ELF load address
@@ -140,7 +188,7 @@ func makeAPEHeader(elfData []byte, elfEntry, elfPhoff uint64, elfPhnum uint16, a
 	// Create Mach-O header for macOS x86-64
 	var machoHeader []byte
 	var machoOffset, machoSize int
-	if arch == sys.AMD64 {
+	if arch == sys.AMD64 && hosts.Has("darwin") {
 		machoHeader = makeMachoHeader(elfData, elfOffset, apeEntry)
 		// Place Mach-O header at a specific location in the APE header
 		// It will be copied backward by the dd command
@@ -165,7 +213,11 @@ func makeAPEHeader(elfData []byte, elfEntry, elfPhoff uint64, elfPhnum uint16, a
 	// The shell script is placed after offset 0x200 to avoid PE header conflicts
 
 	// Write the APE magic at offset 0
-	copy(header[0:8], []byte("MZqFpD='"))
+	if hosts.Has("windows") {
+		copy(header[0:8], apeMagicPE)
+	} else {
+		copy(header[0:8], apeMagicUnix)
+	}
 	header[8] = '\n'
 
 	// Fill bytes 9-59 with safe characters (inside the single-quoted string)
@@ -176,7 +228,9 @@ func makeAPEHeader(elfData []byte, elfEntry, elfPhoff uint64, elfPhnum uint16, a
 
 	// e_lfanew at 0x3C-0x3F - must point to PE header at 0x80
 	// This binary data is inside the single-quoted string (safe)
-	binary.LittleEndian.PutUint32(header[0x3C:], 0x80)
+	if hosts.Has("windows") {
+		binary.LittleEndian.PutUint32(header[0x3C:], 0x80)
+	}
 
 	// Fill bytes 0x40-0x7F with spaces (still in quoted string)
 	for i := 0x40; i < 0x80; i++ {
@@ -217,31 +271,36 @@ func makeAPEHeader(elfData []byte, elfEntry, elfPhoff uint64, elfPhnum uint16, a
 	script.WriteString("' >/dev/null 2>&1\n")
 
 	// Add the main execution logic
+	// APE_PROGRAM tells the runtime which file the user actually ran,
+	// since the kernel only ever sees the extracted copy in $TMPDIR.
+	// APE_PID is the launcher's process ID, which exec preserves; the
+	// runtime ignores APE_PROGRAM unless it names the running process.
 	script.WriteString(`o="$0"
 [ -x "$o" ] || o=$(command -v "$0" 2>/dev/null) || o="$0"
+case "$o" in /*) ;; *) d=$(pwd); o="${d%/}/${o#./}" ;; esac
+APE_PROGRAM="$o"
+APE_PID=$$
+export APE_PROGRAM APE_PID
 case "$(uname -s)" in
-Linux*)
-  t="${TMPDIR:-/tmp}/.ape.$$.$(id -u)"
-  trap 'rm -f "$t"' EXIT
 `)
-	fmt.Fprintf(&script, "  tail -c +%d \"$o\" > \"$t\"\n", elfOffset+1)
-	script.WriteString(`  chmod +x "$t"
-  exec "$t" "$@"
-  ;;
-Darwin*)
+	if hosts.Has("linux") {
+		writeAPEExtract(&script, "Linux*", elfOffset)
+	}
+	if hosts.Has("darwin") {
+		script.WriteString(`Darwin*)
   case "$(uname -m)" in
   x86_64)
 `)
-	if arch == sys.AMD64 && machoSize > 0 {
-		bs := 8
-		skip := machoOffset / bs
-		count := (machoSize + bs - 1) / bs
-		fmt.Fprintf(&script, "    dd if=\"$o\" of=\"$o\" bs=%d skip=%d count=%d conv=notrunc 2>/dev/null\n", bs, skip, count)
-		script.WriteString("    exec \"$o\" \"$@\"\n")
-	} else {
-		script.WriteString("    echo 'APE: macOS x86_64 requires amd64 binary' >&2; exit 1\n")
-	}
-	script.WriteString(`    ;;
+		if arch == sys.AMD64 && machoSize > 0 {
+			bs := 8
+			skip := machoOffset / bs
+			count := (machoSize + bs - 1) / bs
+			fmt.Fprintf(&script, "    dd if=\"$o\" of=\"$o\" bs=%d skip=%d count=%d conv=notrunc 2>/dev/null\n", bs, skip, count)
+			script.WriteString("    exec \"$o\" \"$@\"\n")
+		} else {
+			script.WriteString("    echo 'APE: macOS x86_64 requires amd64 binary' >&2; exit 1\n")
+		}
+		script.WriteString(`    ;;
   arm64)
     if command -v ape >/dev/null 2>&1; then
       exec ape "$o" "$@"
@@ -251,15 +310,18 @@ Darwin*)
     ;;
   esac
   ;;
-FreeBSD*|OpenBSD*|NetBSD*)
-  t="${TMPDIR:-/tmp}/.ape.$$.$(id -u)"
-  trap 'rm -f "$t"' EXIT
 `)
-	fmt.Fprintf(&script, "  tail -c +%d \"$o\" > \"$t\"\n", elfOffset+1)
-	script.WriteString(`  chmod +x "$t"
-  exec "$t" "$@"
-  ;;
-esac
+	}
+	var bsds []string
+	for _, h := range []string{"FreeBSD", "OpenBSD", "NetBSD"} {
+		if hosts.Has(strings.ToLower(h)) {
+			bsds = append(bsds, h+"*")
+		}
+	}
+	if len(bsds) > 0 {
+		writeAPEExtract(&script, strings.Join(bsds, "|"), elfOffset)
+	}
+	script.WriteString(`esac
 exit 1
 `)
 
@@ -267,17 +329,23 @@ exit 1
 
 	// Place script at offset 0x400
 	scriptOffset := 0x400
-	if len(scriptBytes) > apeHeaderSize-scriptOffset {
+	scriptLimit := headerLen
+	if machoSize > 0 {
+		scriptLimit = machoOffset
+	}
+	if len(scriptBytes) > scriptLimit-scriptOffset {
 		Exitf("APE shell script too large: %d bytes", len(scriptBytes))
 	}
 	copy(header[scriptOffset:], scriptBytes)
 
 	// === PE Header at offset 0x80 ===
 	// Required for Windows support
-	writePEHeader(header, arch)
+	if hosts.Has("windows") {
+		writePEHeader(header, arch)
+	}
 
 	// === Mach-O header for macOS x86-64 ===
-	if machoSize > 0 && machoOffset+machoSize <= apeHeaderSize {
+	if machoSize > 0 && machoOffset+machoSize <= headerLen {
 		copy(header[machoOffset:], machoHeader)
 	}
 
@@ -290,7 +358,7 @@ exit 1
 	// Pad remainder with newlines (safe for shell parsing)
 	// Start after the script ends
 	scriptEnd := scriptOffset + len(scriptBytes)
-	for i := scriptEnd; i < apeHeaderSize; i++ {
+	for i := scriptEnd; i < headerLen; i++ {
 		if header[i] == 0 {
 			header[i] = '\n'
 		}
@@ -299,21 +367,45 @@ exit 1
 	return header
 }
 
+// writeAPEExtract writes the case branch of the APE shell script that
+// runs the ELF payload on the hosts matching pattern: it copies the
+// payload to a temporary file and executes that.
+func writeAPEExtract(script *bytes.Buffer, pattern string, elfOffset uint64) {
+	fmt.Fprintf(script, "%s)\n", pattern)
+	script.WriteString(`  t="${TMPDIR:-/tmp}/.ape.$$.$(id -u)"
+  trap 'rm -f "$t"' EXIT
+`)
+	fmt.Fprintf(script, "  tail -c +%d \"$o\" > \"$t\"\n", elfOffset+1)
+	script.WriteString(`  chmod +x "$t"
+  exec "$t" "$@"
+  ;;
+`)
+}
+
 // makeEmbeddedElfHeader creates an ELF header for embedding in the APE printf statement.
 // This header points to the actual ELF segments in the APE file.
 func makeEmbeddedElfHeader(origElf []byte, elfOffset uint64, pageSize uint64, arch sys.ArchFamily) []byte {
+	// Loaders map the segments straight out of the APE file, so each
+	// PT_LOAD must still be congruent with its address modulo the page
+	// size once it has been shifted by the APE header.
+	checkAPESegments(origElf, elfOffset, pageSize)
+
 	// Create a minimal ELF header (64 bytes for ELF64)
 	hdr := make([]byte, 64)
 
 	// ELF magic
 	copy(hdr[0:4], elfMagic)
-	hdr[4] = elfClass64                    // 64-bit
-	hdr[5] = elfDataLSB                    // Little endian
-	hdr[6] = 1                             // ELF version
-	hdr[7] = elfOSABIFreeBSD               // FreeBSD ABI per spec
-
-	// Object file type
-	binary.LittleEndian.PutUint16(hdr[16:], elfTypeExec)
+	hdr[4] = elfClass64      // 64-bit
+	hdr[5] = elfDataLSB      // Little endian
+	hdr[6] = 1               // ELF version
+	hdr[7] = elfOSABIFreeBSD // FreeBSD ABI per spec
+
+	// Object file type: loaders must relocate a PIE, so it keeps ET_DYN
+	etype := uint16(elfTypeExec)
+	if binary.LittleEndian.Uint16(origElf[16:18]) == elfTypeDyn {
+		etype = elfTypeDyn
+	}
+	binary.LittleEndian.PutUint16(hdr[16:], etype)
 
 	// Machine type
 	switch arch {
@@ -354,45 +446,65 @@ func makeEmbeddedElfHeader(origElf []byte, elfOffset uint64, pageSize uint64, ar
 	return hdr
 }
 
+// checkAPESegments verifies that the loadable segments of the ELF
+// payload stay page aligned when placed elfOffset bytes into the APE file.
+func checkAPESegments(elfData []byte, elfOffset, pageSize uint64) {
+	const ptLoad = 1
+	phoff := binary.LittleEndian.Uint64(elfData[32:40])
+	phentsize := uint64(binary.LittleEndian.Uint16(elfData[54:56]))
+	phnum := uint64(binary.LittleEndian.Uint16(elfData[56:58]))
+	for i := uint64(0); i < phnum; i++ {
+		ph := elfData[phoff+i*phentsize:]
+		if binary.LittleEndian.Uint32(ph[0:4]) != ptLoad {
+			continue
+		}
+		off := binary.LittleEndian.Uint64(ph[8:16]) + elfOffset
+		vaddr := binary.LittleEndian.Uint64(ph[16:24])
+		if off%pageSize != vaddr%pageSize {
+			Exitf("APE: segment %d at %#x is not aligned to %d-byte pages", i, vaddr, pageSize)
+		}
+	}
+}
+
 // makeMachoHeader creates a Mach-O header for macOS x86-64.
 func makeMachoHeader(elfData []byte, elfOffset uint64, entry uint64) []byte {
 	var buf bytes.Buffer
 
 	// Mach-O header (32 bytes)
-	binary.Write(&buf, binary.LittleEndian, uint32(machoMagic64))      // magic
-	binary.Write(&buf, binary.LittleEndian, uint32(machoCPUTypeX64))   // cputype
+	binary.Write(&buf, binary.LittleEndian, uint32(machoMagic64))       // magic
+	binary.Write(&buf, binary.LittleEndian, uint32(machoCPUTypeX64))    // cputype
 	binary.Write(&buf, binary.LittleEndian, uint32(machoCPUSubtypeX64)) // cpusubtype
-	binary.Write(&buf, binary.LittleEndian, uint32(machoFileTypeExec)) // filetype
-	binary.Write(&buf, binary.LittleEndian, uint32(2))                 // ncmds (LC_SEGMENT_64 + LC_UNIXTHREAD)
-	binary.Write(&buf, binary.LittleEndian, uint32(72+184))            // sizeofcmds
-	binary.Write(&buf, binary.LittleEndian, uint32(machoFlagNoUndefs)) // flags
-	binary.Write(&buf, binary.LittleEndian, uint32(0))                 // reserved
+	binary.Write(&buf, binary.LittleEndian, uint32(machoFileTypeExec))  // filetype
+	binary.Write(&buf, binary.LittleEndian, uint32(2))                  // ncmds (LC_SEGMENT_64 + LC_UNIXTHREAD)
+	binary.Write(&buf, binary.LittleEndian, uint32(72+184))             // sizeofcmds
+	binary.Write(&buf, binary.LittleEndian, uint32(machoFlagNoUndefs))  // flags
+	binary.Write(&buf, binary.LittleEndian, uint32(0))                  // reserved
 
 	// LC_SEGMENT_64 for __TEXT (72 bytes)
-	binary.Write(&buf, binary.LittleEndian, uint32(machoLCSegment64)) // cmd
-	binary.Write(&buf, binary.LittleEndian, uint32(72))               // cmdsize
-	buf.WriteString("__TEXT\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00")  // segname (16 bytes)
-	binary.Write(&buf, binary.LittleEndian, uint64(0x100000000))      // vmaddr
-	binary.Write(&buf, binary.LittleEndian, uint64(len(elfData)))     // vmsize
-	binary.Write(&buf, binary.LittleEndian, uint64(elfOffset))        // fileoff
-	binary.Write(&buf, binary.LittleEndian, uint64(len(elfData)))     // filesize
+	binary.Write(&buf, binary.LittleEndian, uint32(machoLCSegment64))            // cmd
+	binary.Write(&buf, binary.LittleEndian, uint32(72))                          // cmdsize
+	buf.WriteString("__TEXT\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00")            // segname (16 bytes)
+	binary.Write(&buf, binary.LittleEndian, uint64(0x100000000))                 // vmaddr
+	binary.Write(&buf, binary.LittleEndian, uint64(len(elfData)))                // vmsize
+	binary.Write(&buf, binary.LittleEndian, uint64(elfOffset))                   // fileoff
+	binary.Write(&buf, binary.LittleEndian, uint64(len(elfData)))                // filesize
 	binary.Write(&buf, binary.LittleEndian, uint32(machoProtRead|machoProtExec)) // maxprot
 	binary.Write(&buf, binary.LittleEndian, uint32(machoProtRead|machoProtExec)) // initprot
-	binary.Write(&buf, binary.LittleEndian, uint32(0))                // nsects
-	binary.Write(&buf, binary.LittleEndian, uint32(0))                // flags
+	binary.Write(&buf, binary.LittleEndian, uint32(0))                           // nsects
+	binary.Write(&buf, binary.LittleEndian, uint32(0))                           // flags
 
 	// LC_UNIXTHREAD (184 bytes for x86_64)
 	binary.Write(&buf, binary.LittleEndian, uint32(machoLCUnixThread)) // cmd
-	binary.Write(&buf, binary.LittleEndian, uint32(184))              // cmdsize
-	binary.Write(&buf, binary.LittleEndian, uint32(4))                // flavor (x86_THREAD_STATE64)
-	binary.Write(&buf, binary.LittleEndian, uint32(42))               // count
+	binary.Write(&buf, binary.LittleEndian, uint32(184))               // cmdsize
+	binary.Write(&buf, binary.LittleEndian, uint32(4))                 // flavor (x86_THREAD_STATE64)
+	binary.Write(&buf, binary.LittleEndian, uint32(42))                // count
 
 	// Thread state (42 uint64 values = 336 bytes, but we only write key ones)
 	// Registers: rax, rbx, rcx, rdx, rdi, rsi, rbp, rsp, r8-r15, rip, rflags, cs, fs, gs
 	for i := 0; i < 16; i++ {
 		binary.Write(&buf, binary.LittleEndian, uint64(0)) // rax through r15
 	}
-	binary.Write(&buf, binary.LittleEndian, entry)    // rip (entry point)
+	binary.Write(&buf, binary.LittleEndian, entry)     // rip (entry point)
 	binary.Write(&buf, binary.LittleEndian, uint64(0)) // rflags
 	for i := 0; i < 4; i++ {
 		binary.Write(&buf, binary.LittleEndian, uint64(0)) // cs, fs, gs, etc.
@@ -427,35 +539,35 @@ func writePEHeader(header []byte, arch sys.ArchFamily) {
 
 	// Optional Header (PE32+)
 	optStart := coffStart + 20
-	binary.LittleEndian.PutUint16(header[optStart+0:], 0x20B)       // Magic: PE32+
-	header[optStart+2] = 1                                          // MajorLinkerVersion
-	header[optStart+3] = 0                                          // MinorLinkerVersion
-	binary.LittleEndian.PutUint32(header[optStart+4:], 0x200)       // SizeOfCode
-	binary.LittleEndian.PutUint32(header[optStart+8:], 0)           // SizeOfInitializedData
-	binary.LittleEndian.PutUint32(header[optStart+12:], 0)          // SizeOfUninitializedData
-	binary.LittleEndian.PutUint32(header[optStart+16:], 0x1000)     // AddressOfEntryPoint
-	binary.LittleEndian.PutUint32(header[optStart+20:], 0x1000)     // BaseOfCode
+	binary.LittleEndian.PutUint16(header[optStart+0:], 0x20B)        // Magic: PE32+
+	header[optStart+2] = 1                                           // MajorLinkerVersion
+	header[optStart+3] = 0                                           // MinorLinkerVersion
+	binary.LittleEndian.PutUint32(header[optStart+4:], 0x200)        // SizeOfCode
+	binary.LittleEndian.PutUint32(header[optStart+8:], 0)            // SizeOfInitializedData
+	binary.LittleEndian.PutUint32(header[optStart+12:], 0)           // SizeOfUninitializedData
+	binary.LittleEndian.PutUint32(header[optStart+16:], 0x1000)      // AddressOfEntryPoint
+	binary.LittleEndian.PutUint32(header[optStart+20:], 0x1000)      // BaseOfCode
 	binary.LittleEndian.PutUint64(header[optStart+24:], 0x140000000) // ImageBase
-	binary.LittleEndian.PutUint32(header[optStart+32:], 0x1000)     // SectionAlignment
-	binary.LittleEndian.PutUint32(header[optStart+36:], 0x200)      // FileAlignment
-	binary.LittleEndian.PutUint16(header[optStart+40:], 6)          // MajorOSVersion
-	binary.LittleEndian.PutUint16(header[optStart+42:], 0)          // MinorOSVersion
-	binary.LittleEndian.PutUint16(header[optStart+44:], 0)          // MajorImageVersion
-	binary.LittleEndian.PutUint16(header[optStart+46:], 0)          // MinorImageVersion
-	binary.LittleEndian.PutUint16(header[optStart+48:], 6)          // MajorSubsystemVersion
-	binary.LittleEndian.PutUint16(header[optStart+50:], 0)          // MinorSubsystemVersion
-	binary.LittleEndian.PutUint32(header[optStart+52:], 0)          // Win32VersionValue
-	binary.LittleEndian.PutUint32(header[optStart+56:], 0x2000)     // SizeOfImage
-	binary.LittleEndian.PutUint32(header[optStart+60:], 0x200)      // SizeOfHeaders
-	binary.LittleEndian.PutUint32(header[optStart+64:], 0)          // CheckSum
-	binary.LittleEndian.PutUint16(header[optStart+68:], 3)          // Subsystem: CONSOLE
-	binary.LittleEndian.PutUint16(header[optStart+70:], 0x8160)     // DllCharacteristics
-	binary.LittleEndian.PutUint64(header[optStart+72:], 0x100000)   // SizeOfStackReserve
-	binary.LittleEndian.PutUint64(header[optStart+80:], 0x1000)     // SizeOfStackCommit
-	binary.LittleEndian.PutUint64(header[optStart+88:], 0x100000)   // SizeOfHeapReserve
-	binary.LittleEndian.PutUint64(header[optStart+96:], 0x1000)     // SizeOfHeapCommit
-	binary.LittleEndian.PutUint32(header[optStart+104:], 0)         // LoaderFlags
-	binary.LittleEndian.PutUint32(header[optStart+108:], 16)        // NumberOfRvaAndSizes
+	binary.LittleEndian.PutUint32(header[optStart+32:], 0x1000)      // SectionAlignment
+	binary.LittleEndian.PutUint32(header[optStart+36:], 0x200)       // FileAlignment
+	binary.LittleEndian.PutUint16(header[optStart+40:], 6)           // MajorOSVersion
+	binary.LittleEndian.PutUint16(header[optStart+42:], 0)           // MinorOSVersion
+	binary.LittleEndian.PutUint16(header[optStart+44:], 0)           // MajorImageVersion
+	binary.LittleEndian.PutUint16(header[optStart+46:], 0)           // MinorImageVersion
+	binary.LittleEndian.PutUint16(header[optStart+48:], 6)           // MajorSubsystemVersion
+	binary.LittleEndian.PutUint16(header[optStart+50:], 0)           // MinorSubsystemVersion
+	binary.LittleEndian.PutUint32(header[optStart+52:], 0)           // Win32VersionValue
+	binary.LittleEndian.PutUint32(header[optStart+56:], 0x2000)      // SizeOfImage
+	binary.LittleEndian.PutUint32(header[optStart+60:], 0x200)       // SizeOfHeaders
+	binary.LittleEndian.PutUint32(header[optStart+64:], 0)           // CheckSum
+	binary.LittleEndian.PutUint16(header[optStart+68:], 3)           // Subsystem: CONSOLE
+	binary.LittleEndian.PutUint16(header[optStart+70:], 0x8160)      // DllCharacteristics
+	binary.LittleEndian.PutUint64(header[optStart+72:], 0x100000)    // SizeOfStackReserve
+	binary.LittleEndian.PutUint64(header[optStart+80:], 0x1000)      // SizeOfStackCommit
+	binary.LittleEndian.PutUint64(header[optStart+88:], 0x100000)    // SizeOfHeapReserve
+	binary.LittleEndian.PutUint64(header[optStart+96:], 0x1000)      // SizeOfHeapCommit
+	binary.LittleEndian.PutUint32(header[optStart+104:], 0)          // LoaderFlags
+	binary.LittleEndian.PutUint32(header[optStart+108:], 16)         // NumberOfRvaAndSizes
 
 	// Section Header
 	sectStart := optStart + 240
@@ -0,0 +1,87 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ld
+
+import (
+	"bytes"
+	"cmd/internal/sys"
+	"encoding/binary"
+	"internal/buildcfg"
+	"testing"
+)
+
+func TestAPEHeaderHosts(t *testing.T) {
+	// A bare ELF header with no program headers is enough for
+	// makeAPEHeader, which only copies and adjusts it.
+	elfData := make([]byte, 64)
+	copy(elfData, elfMagic)
+	binary.LittleEndian.PutUint64(elfData[32:], 64)
+	binary.LittleEndian.PutUint16(elfData[54:], 56)
+
+	tests := []struct {
+		gocosmo string
+		goarch  string
+		magic   string
+		size    int
+		has     []string
+		hasNot  []string
+	}{
+		{"linux,freebsd,openbsd,netbsd,darwin,windows", "amd64", apeMagicPE, apeHeaderSize,
+			[]string{"Linux*)", "Darwin*)", "FreeBSD*|OpenBSD*|NetBSD*)", "PE\x00\x00"}, nil},
+		{"linux", "amd64", apeMagicUnix, pageSize4K,
+			[]string{"Linux*)"}, []string{"Darwin", "BSD", "PE\x00\x00"}},
+		{"linux,darwin", "amd64", apeMagicUnix, 2 * pageSize4K,
+			[]string{"Linux*)", "Darwin*)", "dd if="}, []string{"BSD", "PE\x00\x00"}},
+		{"linux,netbsd", "arm64", apeMagicUnix, pageSize16K,
+			[]string{"Linux*)", "NetBSD*)"}, []string{"Darwin", "FreeBSD", "PE\x00\x00"}},
+		{"linux,windows", "arm64", apeMagicPE, apeHeaderSize,
+			[]string{"Linux*)", "PE\x00\x00\x64\xaa"}, []string{"Darwin", "BSD"}},
+	}
+	for _, tt := range tests {
+		hosts, err := buildcfg.ParseGocosmo(tt.gocosmo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		arch := sys.AMD64
+		if tt.goarch == "arm64" {
+			arch = sys.ARM64
+		}
+		header := makeAPEHeader(elfData, 0, 64, 0, arch, hosts)
+		if len(header) != tt.size {
+			t.Errorf("GOCOSMO=%s GOARCH=%s: header is %d bytes; want %d", tt.gocosmo, tt.goarch, len(header), tt.size)
+		}
+		if !bytes.HasPrefix(header, []byte(tt.magic)) {
+			t.Errorf("GOCOSMO=%s GOARCH=%s: header starts with %q; want %q", tt.gocosmo, tt.goarch, header[:8], tt.magic)
+		}
+		for _, s := range tt.has {
+			if !bytes.Contains(header, []byte(s)) {
+				t.Errorf("GOCOSMO=%s GOARCH=%s: header lacks %q", tt.gocosmo, tt.goarch, s)
+			}
+		}
+		for _, s := range tt.hasNot {
+			if bytes.Contains(header, []byte(s)) {
+				t.Errorf("GOCOSMO=%s GOARCH=%s: header contains %q", tt.gocosmo, tt.goarch, s)
+			}
+		}
+	}
+}
+
+func TestAPEEmbeddedElfType(t *testing.T) {
+	for _, etype := range []uint16{elfTypeExec, elfTypeDyn} {
+		elfData := make([]byte, 64)
+		copy(elfData, elfMagic)
+		binary.LittleEndian.PutUint16(elfData[16:], etype)
+		binary.LittleEndian.PutUint64(elfData[32:], 64)
+		binary.LittleEndian.PutUint16(elfData[54:], 56)
+
+		hdr := makeEmbeddedElfHeader(elfData, pageSize4K, pageSize4K, sys.AMD64)
+		if got := binary.LittleEndian.Uint16(hdr[16:]); got != etype {
+			t.Errorf("embedded ELF header has e_type %d; want %d", got, etype)
+		}
+		if got := binary.LittleEndian.Uint64(hdr[32:]); got != 64+pageSize4K {
+			t.Errorf("embedded ELF header has e_phoff %#x; want %#x", got, 64+pageSize4K)
+		}
+	}
+}
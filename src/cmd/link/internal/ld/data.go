@@ -3101,6 +3101,21 @@ func (ctxt *Link) address() []*sym.Segment {
 	ctxt.xdefine("runtime.ecovctrs", sym.SCOVERAGE_COUNTER, int64(noptrbss.Vaddr+covCounterDataStartOff+covCounterDataLen))
 	ctxt.xdefine("runtime.end", sym.SBSS, int64(Segdata.Vaddr+Segdata.Length))
 
+	if ctxt.HeadType == objabi.Hcosmo {
+		// No dynamic loader runs for a cosmo program, so a PIE applies
+		// its own dynamic relocations at startup; see rt0_cosmo_*.s.
+		// Elsewhere the range is empty.
+		start, end := int64(rodata.Vaddr), int64(rodata.Vaddr)
+		if ctxt.BuildMode == BuildModePIE && ctxt.IsInternal() {
+			start = ldr.SymValue(ctxt.Rela)
+			end = start + ldr.SymSize(ctxt.Rela)
+		}
+		ctxt.xdefine("runtime.rela", sym.SRODATA, start)
+		ctxt.xdefine("runtime.erela", sym.SRODATA, end)
+		ldr.SetSymSect(ldr.Lookup("runtime.rela", 0), rodata)
+		ldr.SetSymSect(ldr.Lookup("runtime.erela", 0), rodata)
+	}
+
 	if fuzzCounters != nil {
 		if *flagAsan {
 			// ASAN requires that the symbol marking the end
@@ -1815,7 +1815,9 @@ func asmbElf(ctxt *Link) {
 		Segtext.Filelen += uint64(o)
 	}
 
-	if !*FlagD { // -d suppresses dynamic loader format
+	// Cosmo programs never have an interpreter, not even when they
+	// are position independent and so carry dynamic relocations.
+	if !*FlagD && ctxt.HeadType != objabi.Hcosmo { // -d suppresses dynamic loader format
 		// interpreter
 		sh := elfshname(".interp")
 
@@ -2274,7 +2276,7 @@ elfobj:
 	a += int64(elfwritehdr(ctxt.Out))
 	a += int64(elfwritephdrs(ctxt.Out))
 	a += int64(elfwriteshdrs(ctxt.Out))
-	if !*FlagD {
+	if !*FlagD && ctxt.HeadType != objabi.Hcosmo {
 		a += int64(elfwriteinterp(ctxt.Out))
 	}
 	if ctxt.IsMIPS() {
@@ -1536,6 +1536,16 @@ func (ctxt *Link) hostlink() {
 			argv = append(argv, fmt.Sprintf("-Wl,--major-subsystem-version=%d", PeMinimumTargetMajorVersion))
 			argv = append(argv, fmt.Sprintf("-Wl,--minor-subsystem-version=%d", PeMinimumTargetMinorVersion))
 		}
+	case objabi.Hcosmo:
+		// An APE program carries no dynamic loader that would work
+		// on every host, so the C libraries are linked in statically.
+		// cosmocc does that anyway; a stand-in toolchain needs telling.
+		// A PIE then relocates itself at startup.
+		if ctxt.BuildMode == BuildModePIE {
+			argv = append(argv, "-static-pie", "-pthread")
+		} else {
+			argv = append(argv, "-static", "-pthread")
+		}
 	case objabi.Haix:
 		argv = append(argv, "-pthread")
 		// prevent ld to reorder .text functions to keep the same
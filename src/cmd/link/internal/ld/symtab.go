@@ -446,6 +446,10 @@ func (ctxt *Link) symtab(pcln *pclntab) []sym.SymKind {
 	ctxt.xdefine("runtime.ecovctrs", sym.SNOPTRBSS, 0)
 	ctxt.xdefine("runtime.end", sym.SBSS, 0)
 	ctxt.xdefine("runtime.epclntab", sym.SRODATA, 0)
+	if ctxt.HeadType == objabi.Hcosmo {
+		ctxt.xdefine("runtime.rela", sym.SRODATA, 0)
+		ctxt.xdefine("runtime.erela", sym.SRODATA, 0)
+	}
 
 	// garbage collection symbols
 	s := ldr.CreateSymForUpdate("runtime.gcdata", 0)
@@ -36,6 +36,7 @@ To list the available checks, run "go tool vet help":
 	cgocall          detect some violations of the cgo pointer passing rules
 	composites       check for unkeyed composite literals
 	copylocks        check for locks erroneously passed by value
+	cosmo            report non-portable code in GOOS=cosmo builds
 	defers           report common mistakes in defer statements
 	directive        check Go toolchain directives such as //go:debug
 	errorsas         report passing non-pointer or non-error values to errors.As
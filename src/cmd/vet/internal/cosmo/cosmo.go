@@ -0,0 +1,489 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package cosmo defines an analyzer for code that does not port
+// across the hosts of a GOOS=cosmo executable.
+package cosmo
+
+import (
+	"go/ast"
+	"go/build"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const Doc = `report non-portable code in GOOS=cosmo builds
+
+A GOOS=cosmo executable runs unchanged on Linux, the BSDs, macOS and
+Windows, but nothing stops its source from assuming a Linux host. When
+vetting for GOOS=cosmo, this analyzer reports
+
+  - comparisons of runtime.GOOS with "linux" or "android", which are
+    constant in cosmo builds, and switches on runtime.GOOS with cases
+    for them but none for cosmo;
+  - the Linux pseudo-filesystem paths /proc, /sys and /etc/os-release;
+  - calls of syscall.Syscall and its variants with the number of a
+    system call that only Linux implements.
+
+Code that depends on the host should ask os.HostOS, which reports
+runtime.GOOS on the other ports and so suits portable code. Paths and
+system calls are not reported where the host has been checked: in the
+branches of an if or switch statement whose condition or tag uses the
+result of os.HostOS, and after such an if statement whose body returns.
+The result may be used directly, through a local variable, or through
+a function declared in the same package that calls os.HostOS. The
+check is syntactic; code reached only through calls from a guarded
+branch is still reported.
+
+The analyzer does nothing for other values of GOOS. It skips generated
+files and the packages in GOROOT, which implement the port and consult
+the host themselves.
+`
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "cosmo",
+	Doc:      Doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// linuxOnly lists the system calls, by the name of their syscall.SYS_
+// constant, that no other host of a cosmo executable implements.
+var linuxOnly = map[string]bool{
+	"SYS_ADD_KEY":           true,
+	"SYS_ARCH_PRCTL":        true,
+	"SYS_CAPGET":            true,
+	"SYS_CAPSET":            true,
+	"SYS_CLONE":             true,
+	"SYS_COPY_FILE_RANGE":   true,
+	"SYS_DELETE_MODULE":     true,
+	"SYS_EPOLL_CREATE":      true,
+	"SYS_EPOLL_CREATE1":     true,
+	"SYS_EPOLL_CTL":         true,
+	"SYS_EPOLL_PWAIT":       true,
+	"SYS_EPOLL_WAIT":        true,
+	"SYS_EVENTFD":           true,
+	"SYS_EVENTFD2":          true,
+	"SYS_FANOTIFY_INIT":     true,
+	"SYS_FANOTIFY_MARK":     true,
+	"SYS_FUTEX":             true,
+	"SYS_GETTID":            true,
+	"SYS_INIT_MODULE":       true,
+	"SYS_INOTIFY_ADD_WATCH": true,
+	"SYS_INOTIFY_INIT":      true,
+	"SYS_INOTIFY_INIT1":     true,
+	"SYS_INOTIFY_RM_WATCH":  true,
+	"SYS_IO_CANCEL":         true,
+	"SYS_IO_DESTROY":        true,
+	"SYS_IO_GETEVENTS":      true,
+	"SYS_IO_SETUP":          true,
+	"SYS_IO_SUBMIT":         true,
+	"SYS_KEXEC_LOAD":        true,
+	"SYS_KEYCTL":            true,
+	"SYS_MOUNT":             true,
+	"SYS_PERF_EVENT_OPEN":   true,
+	"SYS_PERSONALITY":       true,
+	"SYS_PIVOT_ROOT":        true,
+	"SYS_PRCTL":             true,
+	"SYS_REQUEST_KEY":       true,
+	"SYS_SET_TID_ADDRESS":   true,
+	"SYS_SIGNALFD":          true,
+	"SYS_SIGNALFD4":         true,
+	"SYS_SPLICE":            true,
+	"SYS_SYSINFO":           true,
+	"SYS_TEE":               true,
+	"SYS_TGKILL":            true,
+	"SYS_TIMERFD_CREATE":    true,
+	"SYS_TIMERFD_GETTIME":   true,
+	"SYS_TIMERFD_SETTIME":   true,
+	"SYS_TKILL":             true,
+	"SYS_UMOUNT2":           true,
+	"SYS_UNSHARE":           true,
+	"SYS_VMSPLICE":          true,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	// The go command runs vet with GOOS set to the target.
+	if build.Default.GOOS != "cosmo" {
+		return nil, nil
+	}
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	g := newGuards(pass)
+
+	nodeFilter := []ast.Node{
+		(*ast.File)(nil),
+		(*ast.BasicLit)(nil),
+		(*ast.BinaryExpr)(nil),
+		(*ast.CallExpr)(nil),
+		(*ast.SwitchStmt)(nil),
+	}
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		switch n := n.(type) {
+		case *ast.File:
+			// Generated files, such as the cosmo z-files, and the
+			// packages that implement the port are not checked.
+			return !ast.IsGenerated(n) && !inGOROOT(pass.Fset.File(n.Pos()).Name())
+		case *ast.BinaryExpr:
+			checkGOOSComparison(pass, n)
+		case *ast.SwitchStmt:
+			checkGOOSSwitch(pass, n)
+		case *ast.BasicLit:
+			if !g.guarded(stack) {
+				checkPath(pass, n)
+			}
+		case *ast.CallExpr:
+			if !g.guarded(stack) {
+				checkSyscall(pass, n)
+			}
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// inGOROOT reports whether filename belongs to a package of the
+// standard library or of cmd. Those packages are the cosmo port: they
+// consult the host where it matters and are tested on every host, so
+// their references to Linux are deliberate. Test data is checked.
+func inGOROOT(filename string) bool {
+	if build.Default.GOROOT == "" {
+		return false
+	}
+	rel, err := filepath.Rel(filepath.Join(build.Default.GOROOT, "src"), filename)
+	if err != nil || !filepath.IsLocal(rel) {
+		return false
+	}
+	return !slices.Contains(strings.Split(filepath.ToSlash(rel), "/"), "testdata")
+}
+
+// isHostQuery reports whether obj asks which operating system is
+// hosting the program.
+func isHostQuery(obj types.Object) bool {
+	fn, ok := obj.(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return false
+	}
+	switch fn.Pkg().Path() {
+	case "os":
+		return fn.Name() == "HostOS"
+	case "internal/hostos":
+		return fn.Name() == "Name"
+	}
+	return false
+}
+
+// guards records what in a package depends on the host, so that code
+// that has checked the host can be told apart.
+type guards struct {
+	info *types.Info
+
+	// funcs holds the functions of the package that call os.HostOS,
+	// directly or through other functions of the package that do.
+	funcs map[types.Object]bool
+
+	// vars holds the variables assigned a value that depends on the
+	// host.
+	vars map[types.Object]bool
+}
+
+func newGuards(pass *analysis.Pass) *guards {
+	g := &guards{
+		info:  pass.TypesInfo,
+		funcs: make(map[types.Object]bool),
+		vars:  make(map[types.Object]bool),
+	}
+
+	calls := make(map[types.Object][]types.Object)
+	for _, f := range pass.Files {
+		for _, d := range f.Decls {
+			decl, ok := d.(*ast.FuncDecl)
+			if !ok || decl.Body == nil {
+				continue
+			}
+			fn := pass.TypesInfo.Defs[decl.Name]
+			ast.Inspect(decl.Body, func(n ast.Node) bool {
+				if call, ok := n.(*ast.CallExpr); ok {
+					if callee := typeutil.Callee(pass.TypesInfo, call); callee != nil {
+						calls[fn] = append(calls[fn], callee)
+					}
+				}
+				return true
+			})
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for fn, callees := range calls {
+			if g.funcs[fn] {
+				continue
+			}
+			for _, callee := range callees {
+				if isHostQuery(callee) || g.funcs[callee] {
+					g.funcs[fn] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	// Variables are assigned before they are used, so one pass in
+	// source order finds those that hold the host.
+	for _, f := range pass.Files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			var lhs []*ast.Ident
+			var rhs []ast.Expr
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				for _, e := range n.Lhs {
+					if id, ok := ast.Unparen(e).(*ast.Ident); ok {
+						lhs = append(lhs, id)
+					}
+				}
+				rhs = n.Rhs
+			case *ast.ValueSpec:
+				lhs, rhs = n.Names, n.Values
+			default:
+				return true
+			}
+			if !g.anyDependsOnHost(rhs) {
+				return true
+			}
+			for _, id := range lhs {
+				if obj := g.info.ObjectOf(id); obj != nil {
+					g.vars[obj] = true
+				}
+			}
+			return true
+		})
+	}
+	return g
+}
+
+// dependsOnHost reports whether n calls os.HostOS, directly or through
+// a function of the package, or uses a variable that holds its result.
+func (g *guards) dependsOnHost(n ast.Node) bool {
+	if n == nil {
+		return false
+	}
+	found := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			if callee := typeutil.Callee(g.info, n); callee != nil && (isHostQuery(callee) || g.funcs[callee]) {
+				found = true
+			}
+		case *ast.Ident:
+			if g.vars[g.info.Uses[n]] {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// anyDependsOnHost reports whether any of list depends on the host.
+func (g *guards) anyDependsOnHost(list []ast.Expr) bool {
+	for _, e := range list {
+		if g.dependsOnHost(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// guarded reports whether the node atop stack only runs after the
+// host has been checked.
+func (g *guards) guarded(stack []ast.Node) bool {
+	for i := 0; i+1 < len(stack); i++ {
+		child := stack[i+1]
+		switch n := stack[i].(type) {
+		case *ast.IfStmt:
+			if (child == n.Body || child == n.Else) && (g.dependsOnHost(n.Init) || g.dependsOnHost(n.Cond)) {
+				return true
+			}
+		case *ast.SwitchStmt:
+			if child != n.Body {
+				continue
+			}
+			if g.dependsOnHost(n.Init) || g.dependsOnHost(n.Tag) {
+				return true
+			}
+			for _, stmt := range n.Body.List {
+				if g.anyDependsOnHost(stmt.(*ast.CaseClause).List) {
+					return true
+				}
+			}
+		case *ast.BlockStmt:
+			if g.checkedBefore(n.List, child) {
+				return true
+			}
+		case *ast.CaseClause:
+			if g.checkedBefore(n.Body, child) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkedBefore reports whether a statement of list that precedes stmt
+// is an if statement that checks the host and returns.
+func (g *guards) checkedBefore(list []ast.Stmt, stmt ast.Node) bool {
+	for _, s := range list {
+		if s == stmt {
+			break
+		}
+		ifStmt, ok := s.(*ast.IfStmt)
+		if !ok || len(ifStmt.Body.List) == 0 {
+			continue
+		}
+		if _, ok := ifStmt.Body.List[len(ifStmt.Body.List)-1].(*ast.ReturnStmt); !ok {
+			continue
+		}
+		if g.dependsOnHost(ifStmt.Init) || g.dependsOnHost(ifStmt.Cond) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRuntimeGOOS reports whether e denotes runtime.GOOS.
+func isRuntimeGOOS(pass *analysis.Pass, e ast.Expr) bool {
+	sel, ok := ast.Unparen(e).(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	obj, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Const)
+	return ok && obj.Pkg() != nil && obj.Pkg().Path() == "runtime" && obj.Name() == "GOOS"
+}
+
+// linuxGOOS returns the value of e if it is a string constant naming
+// a Linux GOOS. A cosmo executable often runs on Linux, which makes
+// comparing runtime.GOOS with those names a likely mistake.
+func linuxGOOS(pass *analysis.Pass, e ast.Expr) (string, bool) {
+	tv := pass.TypesInfo.Types[e]
+	if tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	switch goos := constant.StringVal(tv.Value); goos {
+	case "linux", "android":
+		return goos, true
+	}
+	return "", false
+}
+
+func checkGOOSComparison(pass *analysis.Pass, e *ast.BinaryExpr) {
+	if e.Op != token.EQL && e.Op != token.NEQ {
+		return
+	}
+	other := e.Y
+	if !isRuntimeGOOS(pass, e.X) {
+		if !isRuntimeGOOS(pass, e.Y) {
+			return
+		}
+		other = e.X
+	}
+	goos, ok := linuxGOOS(pass, other)
+	if !ok {
+		return
+	}
+	result := "false"
+	if e.Op == token.NEQ {
+		result = "true"
+	}
+	pass.ReportRangef(e, "runtime.GOOS %s %q is always %s in cosmo builds; use os.HostOS to detect the host", e.Op, goos, result)
+}
+
+func checkGOOSSwitch(pass *analysis.Pass, s *ast.SwitchStmt) {
+	if s.Tag == nil || !isRuntimeGOOS(pass, s.Tag) {
+		return
+	}
+	// A switch with a case for cosmo already handles it.
+	for _, stmt := range s.Body.List {
+		for _, e := range stmt.(*ast.CaseClause).List {
+			if tv := pass.TypesInfo.Types[e]; tv.Value != nil && constant.StringVal(tv.Value) == "cosmo" {
+				return
+			}
+		}
+	}
+	for _, stmt := range s.Body.List {
+		for _, e := range stmt.(*ast.CaseClause).List {
+			if goos, ok := linuxGOOS(pass, e); ok {
+				pass.ReportRangef(e, "case %q never matches runtime.GOOS in cosmo builds; use os.HostOS to detect the host", goos)
+			}
+		}
+	}
+}
+
+// isLinuxPath reports whether path lies in a Linux pseudo-filesystem
+// or is otherwise only found on Linux hosts.
+func isLinuxPath(path string) bool {
+	for _, dir := range []string{"/proc", "/sys"} {
+		if path == dir || strings.HasPrefix(path, dir+"/") {
+			return true
+		}
+	}
+	return path == "/etc/os-release"
+}
+
+func checkPath(pass *analysis.Pass, lit *ast.BasicLit) {
+	if lit.Kind != token.STRING {
+		return
+	}
+	tv := pass.TypesInfo.Types[lit]
+	if tv.Value == nil {
+		return
+	}
+	if path := constant.StringVal(tv.Value); isLinuxPath(path) {
+		pass.ReportRangef(lit, "%q only exists on Linux hosts; check os.HostOS before using it", path)
+	}
+}
+
+func checkSyscall(pass *analysis.Pass, call *ast.CallExpr) {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "syscall" || len(call.Args) == 0 {
+		return
+	}
+	switch fn.Name() {
+	case "Syscall", "Syscall6", "RawSyscall", "RawSyscall6":
+	default:
+		return
+	}
+	trap := pass.TypesInfo.Types[call.Args[0]].Value
+	if trap == nil {
+		return
+	}
+	if name := sysName(fn.Pkg(), trap); linuxOnly[name] {
+		pass.ReportRangef(call.Args[0], "syscall.%s (%s) is a Linux-only system call; check os.HostOS before calling syscall.%s", name, trap, fn.Name())
+	}
+}
+
+// sysName returns the name of the SYS_ constant of the syscall
+// package whose value is trap, or "" if there is none.
+func sysName(syscall *types.Package, trap constant.Value) string {
+	scope := syscall.Scope()
+	for _, name := range scope.Names() {
+		if !strings.HasPrefix(name, "SYS_") {
+			continue
+		}
+		if c, ok := scope.Lookup(name).(*types.Const); ok && constant.Compare(c.Val(), token.EQL, trap) {
+			return name
+		}
+	}
+	return ""
+}
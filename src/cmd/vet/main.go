@@ -7,6 +7,7 @@ package main
 import (
 	"cmd/internal/objabi"
 	"cmd/internal/telemetry/counter"
+	"cmd/vet/internal/cosmo"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/appends"
@@ -69,6 +70,7 @@ var suite = []*analysis.Analyzer{
 	cgocall.Analyzer,
 	composite.Analyzer,
 	copylock.Analyzer,
+	cosmo.Analyzer,
 	defers.Analyzer,
 	directive.Analyzer,
 	errorsas.Analyzer,
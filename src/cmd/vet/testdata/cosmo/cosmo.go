@@ -0,0 +1,92 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains tests for the cosmo checker.
+
+package cosmo
+
+import (
+	"os"
+	"runtime"
+	"syscall"
+)
+
+func GOOSComparison() {
+	if runtime.GOOS == "linux" { // ERROR "runtime.GOOS == \x22linux\x22 is always false in cosmo builds; use os.HostOS to detect the host"
+		println("linux")
+	}
+	if "android" != runtime.GOOS { // ERROR "runtime.GOOS != \x22android\x22 is always true in cosmo builds"
+		println("not android")
+	}
+	if runtime.GOOS == "windows" || runtime.GOOS == "plan9" { // ok: not Linux
+		println("windows")
+	}
+	if runtime.GOOS == "cosmo" {
+		println("cosmo")
+	}
+	switch runtime.GOOS {
+	case "darwin", "linux": // ERROR "case \x22linux\x22 never matches runtime.GOOS in cosmo builds"
+	}
+	switch runtime.GOOS {
+	case "cosmo": // ok: the switch handles cosmo
+	case "linux":
+	}
+}
+
+const osRelease = "/etc/os-release" // ERROR "\x22/etc/os-release\x22 only exists on Linux hosts; check os.HostOS before using it"
+
+func Paths() {
+	os.ReadFile("/proc/self/maps")          // ERROR "\x22/proc/self/maps\x22 only exists on Linux hosts"
+	os.ReadDir(`/sys/class/net`)            // ERROR "\x22/sys/class/net\x22 only exists on Linux hosts"
+	os.Stat("/proc")                        // ERROR "\x22/proc\x22 only exists on Linux hosts"
+	os.Stat("/processes")                   // ok
+	os.Stat("/etc/hosts")                   // ok
+	os.ReadFile(osRelease)                  // ok: reported at the declaration
+	os.ReadFile("/usr/lib/os-release" + "") // ok
+}
+
+func Syscalls() {
+	syscall.Syscall(syscall.SYS_EPOLL_CREATE1, 0, 0, 0)            // ERROR "syscall.SYS_EPOLL_CREATE1 \([0-9]+\) is a Linux-only system call; check os.HostOS before calling syscall.Syscall"
+	syscall.RawSyscall6(syscall.SYS_GETTID, 0, 0, 0, 0, 0, 0)      // ERROR "syscall.SYS_GETTID \([0-9]+\) is a Linux-only system call"
+	syscall.Syscall(uintptr(syscall.SYS_PRCTL), 0, 0, 0)           // ERROR "syscall.SYS_PRCTL \([0-9]+\) is a Linux-only system call"
+	syscall.Syscall(syscall.SYS_GETPID, 0, 0, 0)                   // ok
+	syscall.Syscall6(uintptr(syscall.SYS_WRITE), 1, 0, 0, 0, 0, 0) // ok
+}
+
+// onLinux asks the host, so callers may use Linux features.
+func onLinux() bool {
+	goos, _ := os.HostOS()
+	return goos == "linux"
+}
+
+func Guarded() {
+	if onLinux() {
+		os.ReadFile("/proc/self/maps")
+		syscall.Syscall(syscall.SYS_EPOLL_CREATE1, 0, 0, 0)
+	}
+	if runtime.GOOS == "linux" { // ERROR "runtime.GOOS == \x22linux\x22 is always false"
+		println("linux")
+	}
+	os.ReadFile("/proc/self/status") // ERROR "\x22/proc/self/status\x22 only exists on Linux hosts"
+}
+
+func GuardedByVar() {
+	goos, _ := os.HostOS()
+	switch goos {
+	case "linux":
+		os.ReadFile("/proc/self/maps")
+	}
+	if linux := goos == "linux"; linux {
+		syscall.Syscall(syscall.SYS_GETTID, 0, 0, 0)
+	}
+	os.Stat("/sys") // ERROR "\x22/sys\x22 only exists on Linux hosts"
+}
+
+func GuardedByReturn() {
+	os.Stat("/proc") // ERROR "\x22/proc\x22 only exists on Linux hosts"
+	if !onLinux() {
+		return
+	}
+	os.Stat("/proc")
+}
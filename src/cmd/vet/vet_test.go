@@ -19,8 +19,10 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -34,14 +36,63 @@ func TestMain(m *testing.M) {
 
 	// Set for subprocesses to inherit.
 	os.Setenv("GO_VETTEST_IS_VET", "1") // ignore error
-	os.Exit(m.Run())
+	code := m.Run()
+	if hostVetDir != "" {
+		os.RemoveAll(hostVetDir)
+	}
+	os.Exit(code)
 }
 
 // vetPath returns the path to the "vet" binary to run.
 func vetPath(t testing.TB) string {
+	if runtime.GOOS == "cosmo" {
+		// The go command execs the vet tool directly, which the
+		// host kernel cannot do for an APE binary, so run a vet
+		// built for the host instead of the test binary.
+		path, err := buildHostVet()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
 	return testenv.Executable(t)
 }
 
+// hostVetDir is the temporary directory holding the vet binary
+// built by buildHostVet, if any.
+var hostVetDir string
+
+// buildHostVet builds cmd/vet for GOHOSTOS and GOHOSTARCH and returns
+// the path to the binary.
+var buildHostVet = sync.OnceValues(func() (string, error) {
+	gotool, err := testenv.GoTool()
+	if err != nil {
+		return "", err
+	}
+	out, err := exec.Command(gotool, "env", "GOHOSTOS", "GOHOSTARCH").Output()
+	if err != nil {
+		return "", fmt.Errorf("go env: %v", err)
+	}
+	host := strings.Fields(string(out))
+	if len(host) != 2 {
+		return "", fmt.Errorf("go env: unexpected output %q", out)
+	}
+	hostVetDir, err = os.MkdirTemp("", "vettest")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(hostVetDir, "vet")
+	if host[0] == "windows" {
+		path += ".exe"
+	}
+	cmd := exec.Command(gotool, "build", "-o", path, "cmd/vet")
+	cmd.Env = append(os.Environ(), "GOOS="+host[0], "GOARCH="+host[1])
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("building vet for %s/%s: %v\n%s", host[0], host[1], err, out)
+	}
+	return path, nil
+})
+
 func vetCmd(t *testing.T, arg, pkg string) *exec.Cmd {
 	cmd := testenv.Command(t, testenv.GoToolPath(t), "vet", "-vettool="+vetPath(t), arg, path.Join("cmd/vet/testdata", pkg))
 	cmd.Env = os.Environ()
@@ -60,6 +111,7 @@ func TestVet(t *testing.T) {
 		"cgo",
 		"composite",
 		"copylock",
+		"cosmo",
 		"deadcode",
 		"directive",
 		"hostport",
@@ -93,6 +145,11 @@ func TestVet(t *testing.T) {
 				cmd.Env = append(cmd.Env, "GOOS=linux", "GOARCH=amd64")
 			}
 
+			// The cosmo analyzer only runs for GOOS=cosmo.
+			if pkg == "cosmo" {
+				cmd.Env = append(cmd.Env, "GOOS=cosmo", "GOARCH=amd64")
+			}
+
 			dir := filepath.Join("testdata", pkg)
 			gos, err := filepath.Glob(filepath.Join(dir, "*.go"))
 			if err != nil {
@@ -0,0 +1,56 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build cosmo
+
+// Package bundled reads the CA certificates that Cosmopolitan's own
+// tooling stores in the ZIP archive appended to an APE program.
+// It keeps archive/zip out of crypto/x509 on other ports.
+package bundled
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"strings"
+)
+
+// Dir is the directory of the archive that holds the certificates.
+const Dir = "usr/share/ssl/root/"
+
+// ReadCerts returns the contents of every file under Dir in the ZIP
+// archive embedded in the file named by name. A file that is not a
+// ZIP archive holds no certificates and is not an error.
+func ReadCerts(name string) ([][]byte, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	zr, err := zip.NewReader(f, fi.Size())
+	if err != nil {
+		return nil, nil
+	}
+	var certs [][]byte
+	for _, zf := range zr.File {
+		if !strings.HasPrefix(zf.Name, Dir) || zf.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, data)
+	}
+	return certs, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build cosmo
+
+package x509
+
+import (
+	"crypto/x509/internal/bundled"
+	"os"
+	"slices"
+)
+
+// A Cosmopolitan binary runs unchanged on many Linux distributions,
+// BSDs and macOS, and a host may keep its bundle at another system's
+// path, so every host probes the union of the lists below.
+
+// Possible certificate files; stop after finding one.
+var certFiles = union(linuxCertFiles, bsdCertFiles)
+
+// Possible directories with certificate files; all will be read.
+var certDirectories = union(linuxCertDirectories, bsdCertDirectories)
+
+// From root_linux.go.
+var (
+	linuxCertFiles = []string{
+		"/etc/ssl/certs/ca-certificates.crt",                // Debian/Ubuntu/Gentoo etc.
+		"/etc/pki/tls/certs/ca-bundle.crt",                  // Fedora/RHEL 6
+		"/etc/ssl/ca-bundle.pem",                            // OpenSUSE
+		"/etc/pki/tls/cacert.pem",                           // OpenELEC
+		"/etc/pki/ca-trust/extracted/pem/tls-ca-bundle.pem", // CentOS/RHEL 7
+		"/etc/ssl/cert.pem",                                 // Alpine Linux
+	}
+	linuxCertDirectories = []string{
+		"/etc/ssl/certs",     // SLES10/SLES11
+		"/etc/pki/tls/certs", // Fedora/RHEL
+	}
+)
+
+// From root_bsd.go, which also suits macOS.
+var (
+	bsdCertFiles = []string{
+		"/usr/local/etc/ssl/cert.pem",            // FreeBSD
+		"/etc/ssl/cert.pem",                      // OpenBSD, macOS
+		"/usr/local/share/certs/ca-root-nss.crt", // DragonFly
+		"/etc/openssl/certs/ca-certificates.crt", // NetBSD
+	}
+	bsdCertDirectories = []string{
+		"/etc/ssl/certs",         // FreeBSD 12.2+
+		"/usr/local/share/certs", // FreeBSD
+		"/etc/openssl/certs",     // NetBSD
+	}
+)
+
+// union returns the elements of a followed by those of b not in a.
+func union(a, b []string) []string {
+	u := slices.Clip(a)
+	for _, s := range b {
+		if !slices.Contains(a, s) {
+			u = append(u, s)
+		}
+	}
+	return u
+}
+
+func init() {
+	loadBundledRoots = func(roots *CertPool) error {
+		exe, err := os.Executable()
+		if err != nil {
+			return nil
+		}
+		return loadZipRoots(roots, exe)
+	}
+}
+
+// loadZipRoots adds every certificate that Cosmopolitan's tooling
+// bundled in the ZIP archive embedded in the file named by name.
+func loadZipRoots(roots *CertPool, name string) error {
+	certs, err := bundled.ReadCerts(name)
+	for _, data := range certs {
+		roots.AppendCertsFromPEM(data)
+	}
+	return err
+}
@@ -0,0 +1,89 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build cosmo
+
+package x509
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/x509/internal/bundled"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// TestCosmoCertPaths checks that every host probes both the Linux and
+// the BSD locations, each once.
+func TestCosmoCertPaths(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		got  []string
+		want [][]string
+	}{
+		{"certFiles", certFiles, [][]string{linuxCertFiles, bsdCertFiles}},
+		{"certDirectories", certDirectories, [][]string{linuxCertDirectories, bsdCertDirectories}},
+	} {
+		for _, list := range tt.want {
+			for _, s := range list {
+				i := slices.Index(tt.got, s)
+				if i < 0 {
+					t.Errorf("%s is missing %s", tt.name, s)
+				} else if slices.Contains(tt.got[i+1:], s) {
+					t.Errorf("%s lists %s more than once", tt.name, s)
+				}
+			}
+		}
+	}
+}
+
+func TestLoadZipRoots(t *testing.T) {
+	testCert, err := os.ReadFile("testdata/test-dir.crt")
+	if err != nil {
+		t.Fatalf("failed to read test cert: %s", err)
+	}
+
+	// Mimic an APE binary: some executable bytes followed by a ZIP archive.
+	var buf bytes.Buffer
+	buf.WriteString("MZqFpD='\n")
+	buf.Write(make([]byte, 4096))
+	zw := zip.NewWriter(&buf)
+	zw.SetOffset(int64(buf.Len()))
+	for name, data := range map[string][]byte{
+		bundled.Dir + "test-dir.pem":   testCert,
+		"usr/share/other/test-dir.pem": testCert,
+	} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(data)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	exe := filepath.Join(t.TempDir(), "prog.com")
+	if err := os.WriteFile(exe, buf.Bytes(), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	roots := NewCertPool()
+	if err := loadZipRoots(roots, exe); err != nil {
+		t.Fatalf("loadZipRoots: %v", err)
+	}
+	if roots.len() != 1 {
+		t.Errorf("loadZipRoots loaded %d roots, want 1", roots.len())
+	}
+
+	// A binary without an archive has no bundled roots.
+	if err := os.WriteFile(exe, []byte("MZqFpD='\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	roots = NewCertPool()
+	if err := loadZipRoots(roots, exe); err != nil || roots.len() != 0 {
+		t.Errorf("loadZipRoots on plain binary = %d roots, %v; want 0, nil", roots.len(), err)
+	}
+}
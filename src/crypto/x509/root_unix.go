@@ -2,7 +2,7 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-//go:build aix || dragonfly || freebsd || (js && wasm) || linux || netbsd || openbsd || solaris || wasip1
+//go:build aix || cosmo || dragonfly || freebsd || (js && wasm) || linux || netbsd || openbsd || solaris || wasip1
 
 package x509
 
@@ -25,6 +25,10 @@ const (
 	certDirEnv = "SSL_CERT_DIR"
 )
 
+// loadBundledRoots, if non-nil, adds roots shipped inside the program
+// itself. It is consulted only when no other roots were found.
+var loadBundledRoots func(roots *CertPool) error
+
 func (c *Certificate) systemVerify(opts *VerifyOptions) (chains [][]*Certificate, err error) {
 	return nil, nil
 }
@@ -74,6 +78,12 @@ func loadSystemRoots() (*CertPool, error) {
 		}
 	}
 
+	if roots.len() == 0 && loadBundledRoots != nil {
+		if err := loadBundledRoots(roots); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
 	if roots.len() > 0 || firstErr == nil {
 		return roots, nil
 	}
@@ -128,6 +128,12 @@ func readRawBuildInfo(r io.ReaderAt) (vers, mod string, err error) {
 			return "", "", errUnrecognizedFormat
 		}
 		x = &elfExe{f}
+	case isAPE(ident):
+		f, err := newAPEFile(r)
+		if err != nil {
+			return "", "", errUnrecognizedFormat
+		}
+		x = &elfExe{f}
 	case bytes.HasPrefix(ident, []byte("MZ")):
 		f, err := pe.NewFile(r)
 		if err != nil {
@@ -466,6 +472,36 @@ func (x *elfExe) DataStart() (uint64, uint64) {
 	return 0, 0
 }
 
+// apeMagic lists the first bytes of an Actually Portable Executable,
+// as built for GOOS=cosmo. The first also starts a PE image.
+var apeMagic = [...]string{"MZqFpD='", "jartsr='", "APEDBG='"}
+
+func isAPE(ident []byte) bool {
+	for _, m := range apeMagic {
+		if bytes.HasPrefix(ident, []byte(m)) {
+			return true
+		}
+	}
+	return false
+}
+
+// newAPEFile opens the ELF image inside an Actually Portable
+// Executable. The image follows the APE header, which is a whole
+// number of 4KB pages and at most 64KB long.
+func newAPEFile(r io.ReaderAt) (*elf.File, error) {
+	const (
+		pageSize     = 4 << 10
+		maxHeaderLen = 64 << 10
+	)
+	magic := make([]byte, 4)
+	for off := int64(pageSize); off <= maxHeaderLen; off += pageSize {
+		if n, _ := r.ReadAt(magic, off); n == len(magic) && string(magic) == "\x7FELF" {
+			return elf.NewFile(io.NewSectionReader(r, off, 1<<63-1-off))
+		}
+	}
+	return nil, errUnrecognizedFormat
+}
+
 // peExe is the PE (Windows Portable Executable) implementation of the exe interface.
 type peExe struct {
 	f *pe.File
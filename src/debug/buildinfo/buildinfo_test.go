@@ -38,6 +38,8 @@ func TestReadFile(t *testing.T) {
 	type platform struct{ goos, goarch string }
 	platforms := []platform{
 		{"aix", "ppc64"},
+		{"cosmo", "amd64"},
+		{"cosmo", "arm64"},
 		{"darwin", "amd64"},
 		{"darwin", "arm64"},
 		{"linux", "386"},
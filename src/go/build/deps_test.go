@@ -51,6 +51,7 @@ var depsRules = `
 	  internal/cpu,
 	  internal/goarch,
 	  internal/godebugs,
+	  internal/gocosmo,
 	  internal/goexperiment,
 	  internal/goos,
 	  internal/goversion,
@@ -81,6 +82,7 @@ var depsRules = `
 	internal/coverage/rtcov,
 	internal/cpu,
 	internal/goarch,
+	internal/gocosmo,
 	internal/godebugs,
 	internal/goexperiment,
 	internal/goos,
@@ -98,6 +100,7 @@ var depsRules = `
 	< internal/msan
 	< internal/asan
 	< internal/runtime/sys
+	< internal/runtime/syscall/cosmo
 	< internal/runtime/syscall/linux
 	< internal/runtime/syscall/windows
 	< internal/runtime/atomic
@@ -108,6 +111,7 @@ var depsRules = `
 	< internal/runtime/cgroup
 	< internal/runtime/gc/scan
 	< runtime
+	< internal/hostos
 	< runtime/secret
 	< sync/atomic
 	< internal/sync
@@ -609,8 +613,12 @@ var depsRules = `
 	CRYPTO-MATH, golang.org/x/crypto/chacha20poly1305
 	< crypto/hpke;
 
+	# Only cosmo's crypto/x509 reads roots from a ZIP archive.
+	OS, archive/zip < crypto/x509/internal/bundled;
+
 	CRYPTO-MATH, NET, container/list, encoding/hex, encoding/pem, crypto/hpke,
-	golang.org/x/crypto/chacha20poly1305, crypto/tls/internal/fips140tls
+	golang.org/x/crypto/chacha20poly1305, crypto/tls/internal/fips140tls,
+	crypto/x509/internal/bundled
 	< crypto/x509/internal/macos
 	< crypto/x509/pkix
 	< crypto/x509
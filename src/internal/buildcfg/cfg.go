@@ -15,6 +15,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -32,6 +33,7 @@ var (
 	GOPPC64   = goppc64()
 	GORISCV64 = goriscv64()
 	GOWASM    = gowasm()
+	GOCOSMO   = gocosmo()
 	ToolTags  = toolTags()
 	GO_LDSO   = defaultGO_LDSO
 	GOFIPS140 = gofips140()
@@ -357,6 +359,59 @@ func gowasm() (f gowasmFeatures) {
 	return
 }
 
+// CosmoHosts lists the host operating systems that a GOOS=cosmo
+// binary can support, in the order in which GOCOSMO settings are
+// written.
+var CosmoHosts = []string{"linux", "freebsd", "openbsd", "netbsd", "darwin", "windows"}
+
+// GocosmoHosts is the set of host operating systems that a GOOS=cosmo
+// binary is built to run on, as selected by GOCOSMO.
+type GocosmoHosts struct {
+	hosts uint8 // bit i is set if CosmoHosts[i] is selected
+}
+
+// Has reports whether goos is one of the selected hosts.
+func (g GocosmoHosts) Has(goos string) bool {
+	for i, h := range CosmoHosts {
+		if h == goos {
+			return g.hosts&(1<<i) != 0
+		}
+	}
+	return false
+}
+
+func (g GocosmoHosts) String() string {
+	var hosts []string
+	for i, h := range CosmoHosts {
+		if g.hosts&(1<<i) != 0 {
+			hosts = append(hosts, h)
+		}
+	}
+	return strings.Join(hosts, ",")
+}
+
+// ParseGocosmo parses a GOCOSMO setting, a comma-separated list of
+// host operating systems taken from CosmoHosts.
+func ParseGocosmo(v string) (g GocosmoHosts, e error) {
+	for h := range strings.SplitSeq(v, ",") {
+		i := slices.Index(CosmoHosts, h)
+		if i < 0 {
+			return GocosmoHosts{}, fmt.Errorf("invalid GOCOSMO: %q is not one of %s", h, strings.Join(CosmoHosts, ", "))
+		}
+		g.hosts |= 1 << i
+	}
+	return g, nil
+}
+
+func gocosmo() (g GocosmoHosts) {
+	g, err := ParseGocosmo(envOr("GOCOSMO", DefaultGOCOSMO))
+	if err != nil {
+		Error = err
+		g, _ = ParseGocosmo(DefaultGOCOSMO)
+	}
+	return g
+}
+
 func Getgoextlinkenabled() string {
 	return envOr("GO_EXTLINK_ENABLED", defaultGO_EXTLINK_ENABLED)
 }
@@ -364,6 +419,7 @@ func Getgoextlinkenabled() string {
 func toolTags() []string {
 	tags := experimentTags()
 	tags = append(tags, gogoarchTags()...)
+	tags = append(tags, gogoosTags()...)
 	return tags
 }
 
@@ -406,6 +462,30 @@ func GOGOARCH() (name, value string) {
 	return "", ""
 }
 
+// GOGOOS returns the name and value of the GO$GOOS setting.
+// For example, if GOOS is "cosmo" it might return "GOCOSMO", "linux,freebsd".
+func GOGOOS() (name, value string) {
+	switch GOOS {
+	case "cosmo":
+		return "GOCOSMO", GOCOSMO.String()
+	}
+	return "", ""
+}
+
+func gogoosTags() []string {
+	switch GOOS {
+	case "cosmo":
+		var list []string
+		for _, h := range CosmoHosts {
+			if GOCOSMO.Has(h) {
+				list = append(list, GOOS+"."+h)
+			}
+		}
+		return list
+	}
+	return nil
+}
+
 func gogoarchTags() []string {
 	switch GOARCH {
 	case "386":
@@ -6,6 +6,7 @@ package buildcfg
 
 import (
 	"os"
+	"slices"
 	"testing"
 )
 
@@ -128,6 +129,55 @@ func TestGogoarchTags(t *testing.T) {
 	GOARM64 = old_goarm64
 }
 
+func TestGocosmo(t *testing.T) {
+	for _, tt := range []struct {
+		in, want string
+	}{
+		{"linux", "linux"},
+		{"windows,linux", "linux,windows"},
+		{"linux,linux", "linux"},
+		{"linux,freebsd,openbsd,netbsd,darwin,windows", "linux,freebsd,openbsd,netbsd,darwin,windows"},
+	} {
+		g, err := ParseGocosmo(tt.in)
+		if err != nil || g.String() != tt.want {
+			t.Errorf("ParseGocosmo(%q) = %q, %v; want %q", tt.in, g, err, tt.want)
+		}
+	}
+	for _, in := range []string{"", "linux,", "bsd", "Linux", "linux,macos"} {
+		if _, err := ParseGocosmo(in); err == nil {
+			t.Errorf("ParseGocosmo(%q) succeeded; want error", in)
+		}
+	}
+
+	g, _ := ParseGocosmo("linux,darwin")
+	if !g.Has("linux") || !g.Has("darwin") || g.Has("windows") || g.Has("cosmo") {
+		t.Errorf("Has reports the wrong hosts for %q", g)
+	}
+}
+
+func TestGogoosTags(t *testing.T) {
+	old_goos := GOOS
+	old_gocosmo := GOCOSMO
+	defer func() {
+		GOOS = old_goos
+		GOCOSMO = old_gocosmo
+	}()
+
+	GOOS = "cosmo"
+	GOCOSMO, _ = ParseGocosmo("windows,linux")
+	if tags, want := gogoosTags(), []string{"cosmo.linux", "cosmo.windows"}; !slices.Equal(tags, want) {
+		t.Errorf("gogoosTags() = %q for GOCOSMO=windows,linux; want %q", tags, want)
+	}
+	if k, v := GOGOOS(); k != "GOCOSMO" || v != "linux,windows" {
+		t.Errorf("GOGOOS() = %q, %q; want \"GOCOSMO\", \"linux,windows\"", k, v)
+	}
+
+	GOOS = "linux"
+	if tags := gogoosTags(); tags != nil {
+		t.Errorf("gogoosTags() = %q for GOOS=linux; want none", tags)
+	}
+}
+
 var goodFIPS = []string{
 	"v1.0.0",
 	"v1.0.1",
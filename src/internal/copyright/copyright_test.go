@@ -26,6 +26,7 @@ var permitted = [][]byte{
 	[]byte("// Original source:\n//\thttp://www.zorinaq.com/papers/md5-amd64.html"), // public domain crypto/md5
 	[]byte("// created by cgo -cdefs"),
 	[]byte("// go run mkasm.go"),
+	[]byte("// go run mkcosmo.go"),
 	[]byte("// mkerrors"),
 	[]byte("// mksys"),
 	[]byte("// run\n// Code generated by"), // cmd/compile/internal/test/constFold_test.go
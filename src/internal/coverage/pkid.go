@@ -53,6 +53,7 @@ var rtPkgs = [...]string{
 	"internal/goarch",
 	"internal/abi",
 	"internal/chacha8rand",
+	"internal/gocosmo",
 	"internal/godebugs",
 	"internal/goexperiment",
 	"internal/goos",
@@ -0,0 +1,19 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package gocosmo reports the host operating systems that a GOOS=cosmo
+// binary is built to run on, as selected by the GOCOSMO setting.
+//
+// Each host has a boolean constant that is true if GOCOSMO lists it,
+// so code for the other hosts compiles away. The runtime uses them to
+// refuse to start on a host that GOCOSMO left out. The constants are
+// false for every other GOOS.
+//
+// The constants are derived from the cosmo.<host> build tags that the
+// go command sets for GOCOSMO. To add a host, add it to
+// internal/buildcfg.CosmoHosts and to the list in mkconsts.go, and run
+// "go generate".
+package gocosmo
+
+//go:generate go run mkconsts.go
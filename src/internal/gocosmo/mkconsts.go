@@ -0,0 +1,77 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build ignore
+
+// mkconsts generates const definition files for each GOCOSMO host.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+// hosts maps the GOCOSMO host names to the names of their constants.
+var hosts = []struct{ host, name string }{
+	{"linux", "Linux"},
+	{"freebsd", "FreeBSD"},
+	{"openbsd", "OpenBSD"},
+	{"netbsd", "NetBSD"},
+	{"darwin", "Darwin"},
+	{"windows", "Windows"},
+}
+
+func main() {
+	// Delete existing host constant files.
+	ents, err := os.ReadDir(".")
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, ent := range ents {
+		name := ent.Name()
+		if !strings.HasPrefix(name, "host_") {
+			continue
+		}
+		// Check that this is definitely a generated file.
+		data, err := os.ReadFile(name)
+		if err != nil {
+			log.Fatalf("reading %s: %v", name, err)
+		}
+		if !bytes.Contains(data, []byte("Code generated by mkconsts")) {
+			log.Fatalf("%s: expected generated file", name)
+		}
+		if err := os.Remove(name); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// Generate new host constant files.
+	for _, h := range hosts {
+		buildTag := "cosmo." + h.host
+		for _, val := range []bool{false, true} {
+			name := fmt.Sprintf("host_%s_%s.go", h.host, pick(val, "off", "on"))
+			data := fmt.Sprintf(`// Code generated by mkconsts.go. DO NOT EDIT.
+
+//go:build %s%s
+
+package gocosmo
+
+const %s = %v
+`, pick(val, "!", ""), buildTag, h.name, val)
+			if err := os.WriteFile(name, []byte(data), 0666); err != nil {
+				log.Fatalf("writing %s: %v", name, err)
+			}
+		}
+	}
+}
+
+func pick(v bool, f, t string) string {
+	if v {
+		return t
+	}
+	return f
+}
@@ -0,0 +1,60 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package hostos reports which operating system is hosting the program.
+//
+// For most ports that is simply runtime.GOOS. A GOOS=cosmo executable,
+// however, runs unchanged on Linux, the BSDs, macOS and Windows, so the
+// runtime asks the kernel with uname(2) during osinit and packages that
+// need to behave natively on each host consult this package rather than
+// runtime.GOOS.
+package hostos
+
+// Name returns the host operating system as a GOOS value, such as
+// "linux", "freebsd", "darwin" or "windows", or "" if it is not known.
+func Name() string {
+	return name()
+}
+
+// Release returns the release of the host kernel as reported by
+// uname(2), such as "6.1.0-13-amd64" or "14.1-RELEASE", or "" if it
+// is not known.
+func Release() string {
+	return release
+}
+
+// Version returns the major and minor numbers at the start of Release,
+// or (0, 0) if they cannot be parsed.
+func Version() (major, minor int) {
+	return ParseVersion(release)
+}
+
+// ParseVersion returns the major and minor numbers at the start of a
+// kernel release such as "6.1.0-13-amd64", or (0, 0) if they cannot
+// be parsed.
+func ParseVersion(release string) (major, minor int) {
+	var (
+		values    [2]int
+		value, vi int
+	)
+	for i := 0; i < len(release); i++ {
+		c := release[i]
+		if '0' <= c && c <= '9' {
+			value = (value * 10) + int(c-'0')
+		} else {
+			// Note that we're assuming N.N.N here.
+			// If we see anything else, we are likely to mis-parse it.
+			values[vi] = value
+			vi++
+			if vi >= len(values) {
+				break
+			}
+			value = 0
+		}
+	}
+	if vi < len(values) {
+		values[vi] = value
+	}
+	return values[0], values[1]
+}
@@ -0,0 +1,35 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build cosmo
+
+package hostos
+
+import _ "unsafe" // for linkname
+
+// sysname and release are the uname(2) fields of the host kernel.
+//
+//go:linkname sysname
+var sysname string // set by osinit in ../../runtime/os_cosmo.go
+
+//go:linkname release
+var release string // set by osinit in ../../runtime/os_cosmo.go
+
+func name() string {
+	switch sysname {
+	case "Linux":
+		return "linux"
+	case "FreeBSD":
+		return "freebsd"
+	case "OpenBSD":
+		return "openbsd"
+	case "NetBSD":
+		return "netbsd"
+	case "Darwin", "XNU":
+		return "darwin"
+	case "Windows", "Windows_NT":
+		return "windows"
+	}
+	return ""
+}
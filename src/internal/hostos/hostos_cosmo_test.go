@@ -0,0 +1,44 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hostos_test
+
+import (
+	"internal/hostos"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestHostLinux(t *testing.T) {
+	// Only Linux has /proc/sys/kernel/osrelease, which makes it an
+	// independent witness of both the host and its release.
+	b, err := os.ReadFile("/proc/sys/kernel/osrelease")
+	if err != nil {
+		t.Skipf("not a Linux host: %v", err)
+	}
+	want := strings.TrimSpace(string(b))
+
+	if got := hostos.Name(); got != "linux" {
+		t.Errorf("Name() = %q; want %q", got, "linux")
+	}
+	if got := hostos.Release(); got != want {
+		t.Errorf("Release() = %q; want %q", got, want)
+	}
+	if goos, release := os.HostOS(); goos != "linux" || release != want {
+		t.Errorf("os.HostOS() = %q, %q; want %q, %q", goos, release, "linux", want)
+	}
+
+	// The release starts with "major.minor", as in "6.1.0-13-amd64".
+	nums := strings.FieldsFunc(want, func(r rune) bool { return r < '0' || r > '9' })
+	if len(nums) < 2 {
+		t.Fatalf("cannot parse release %q", want)
+	}
+	major, _ := strconv.Atoi(nums[0])
+	minor, _ := strconv.Atoi(nums[1])
+	if gotMajor, gotMinor := hostos.Version(); gotMajor != major || gotMinor != minor {
+		t.Errorf("Version() = %d.%d; want %d.%d from %q", gotMajor, gotMinor, major, minor, want)
+	}
+}
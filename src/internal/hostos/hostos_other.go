@@ -0,0 +1,17 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !cosmo
+
+package hostos
+
+import "runtime"
+
+// Outside cosmo the host is the target, and the kernel release is
+// not collected.
+var release string
+
+func name() string {
+	return runtime.GOOS
+}
@@ -0,0 +1,28 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hostos_test
+
+import (
+	"internal/hostos"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	for _, tt := range []struct {
+		release      string
+		major, minor int
+	}{
+		{"6.1.0-13-amd64", 6, 1},
+		{"5.15.153.1-microsoft-standard-WSL2", 5, 15},
+		{"14.1-RELEASE", 14, 1},
+		{"7.5", 7, 5},
+		{"23", 23, 0},
+		{"", 0, 0},
+	} {
+		if major, minor := hostos.ParseVersion(tt.release); major != tt.major || minor != tt.minor {
+			t.Errorf("ParseVersion(%q) = %d, %d; want %d, %d", tt.release, major, minor, tt.major, tt.minor)
+		}
+	}
+}
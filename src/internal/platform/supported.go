@@ -26,7 +26,7 @@ func RaceDetectorSupported(goos, goarch string) bool {
 		return goarch == "amd64" || goarch == "arm64" || goarch == "loong64" || goarch == "ppc64le" || goarch == "riscv64" || goarch == "s390x"
 	case "darwin":
 		return goarch == "amd64" || goarch == "arm64"
-	case "freebsd", "netbsd", "windows":
+	case "cosmo", "freebsd", "netbsd", "windows":
 		return goarch == "amd64"
 	default:
 		return false
@@ -105,6 +105,11 @@ func MustLinkExternal(goos, goarch string, withCgo bool) bool {
 			// set up by the dynamic linker, so internal cgo linking
 			// doesn't work. Test case is "go test runtime/cgo".
 			return true
+		case "cosmo":
+			// APE programs are static, and the C library has to
+			// come from the cosmocc toolchain, so cgo code is
+			// always linked by it.
+			return true
 		}
 	}
 
@@ -157,6 +162,8 @@ func BuildModeSupported(compiler, buildmode, goos, goarch string) bool {
 				// in a PIE or shared library.
 				return false
 			}
+		case "cosmo":
+			return goarch == "amd64" || goarch == "arm64"
 		case "freebsd":
 			return goarch == "amd64"
 		}
@@ -184,6 +191,7 @@ func BuildModeSupported(compiler, buildmode, goos, goarch string) bool {
 		switch platform {
 		case "linux/386", "linux/amd64", "linux/arm", "linux/arm64", "linux/loong64", "linux/ppc64le", "linux/riscv64", "linux/s390x",
 			"android/amd64", "android/arm", "android/arm64", "android/386",
+			"cosmo/amd64", "cosmo/arm64",
 			"freebsd/amd64",
 			"darwin/amd64", "darwin/arm64",
 			"ios/amd64", "ios/arm64",
@@ -219,6 +227,7 @@ func BuildModeSupported(compiler, buildmode, goos, goarch string) bool {
 func InternalLinkPIESupported(goos, goarch string) bool {
 	switch goos + "/" + goarch {
 	case "android/arm64",
+		"cosmo/amd64", "cosmo/arm64",
 		"darwin/amd64", "darwin/arm64",
 		"linux/amd64", "linux/arm64", "linux/loong64", "linux/ppc64le",
 		"windows/386", "windows/amd64", "windows/arm64":
@@ -68,8 +68,8 @@ var distInfo = map[OSArch]osArchInfo{
 	{"android", "amd64"}:   {CgoSupported: true},
 	{"android", "arm"}:     {CgoSupported: true},
 	{"android", "arm64"}:   {CgoSupported: true},
-	{"cosmo", "amd64"}:     {},
-	{"cosmo", "arm64"}:     {},
+	{"cosmo", "amd64"}:     {CgoSupported: true},
+	{"cosmo", "arm64"}:     {CgoSupported: true},
 	{"darwin", "amd64"}:    {CgoSupported: true, FirstClass: true},
 	{"darwin", "arm64"}:    {CgoSupported: true, FirstClass: true},
 	{"dragonfly", "amd64"}: {CgoSupported: true},
@@ -2,6 +2,8 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+//go:build cosmo || linux
+
 package poll
 
 import (
@@ -14,7 +16,7 @@ var supportCopyFileRange = sync.OnceValue(func() bool {
 	// copy_file_range(2) is broken in various ways on kernels older than 5.3,
 	// see https://go.dev/issue/42400 and
 	// https://man7.org/linux/man-pages/man2/copy_file_range.2.html#VERSIONS
-	return unix.KernelVersionGE(5, 3)
+	return unix.LinuxKernelVersionGE(5, 3)
 })
 
 // For best performance, call copy_file_range() with the largest len value
@@ -2,7 +2,9 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-// Export guts for testing on linux.
+//go:build cosmo || linux
+
+// Export guts for testing on linux and cosmo.
 // Since testing imports os and os imports internal/poll,
 // the internal/poll tests can not be in package poll.
 
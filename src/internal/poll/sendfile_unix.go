@@ -2,7 +2,7 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-//go:build darwin || dragonfly || freebsd || linux || solaris
+//go:build cosmo || darwin || dragonfly || freebsd || linux || solaris
 
 package poll
 
@@ -28,10 +28,11 @@ import (
 // has not modified the source or destination,
 // and the caller should perform the copy using a fallback implementation.
 func SendFile(dstFD *FD, src uintptr, size int64) (n int64, err error, handled bool) {
-	if goos := runtime.GOOS; goos == "linux" || goos == "android" {
+	if goos := runtime.GOOS; goos == "linux" || goos == "android" || goos == "cosmo" {
 		// Linux's sendfile doesn't require any setup:
 		// It sends from the current position of the source file and
 		// updates the position of the source after sending.
+		// On cosmo, callers only use sendfile on Linux hosts.
 		return sendFile(dstFD, int(src), nil, size)
 	}
 
@@ -132,7 +133,7 @@ func sendFile(dstFD *FD, src int, offset *int64, size int64) (written int64, err
 
 func sendFileChunk(dst, src int, offset *int64, size int, written int64) (n int, err error) {
 	switch runtime.GOOS {
-	case "linux", "android":
+	case "linux", "android", "cosmo":
 		// The offset is always nil on Linux.
 		n, err = syscall.Sendfile(dst, src, offset, size)
 	case "solaris", "illumos":
@@ -2,6 +2,8 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+//go:build cosmo || linux
+
 package poll
 
 import (
@@ -31,6 +33,9 @@ const (
 // Splice gets a pipe buffer from the pool or creates a new one if needed, to serve as a buffer for the data transfer.
 // src and dst must both be stream-oriented sockets.
 func Splice(dst, src *FD, remain int64) (written int64, handled bool, err error) {
+	if !unix.LinuxHost() {
+		return 0, false, nil
+	}
 	p, err := getPipe()
 	if err != nil {
 		return 0, false, err
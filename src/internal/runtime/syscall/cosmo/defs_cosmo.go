@@ -51,3 +51,13 @@ type EpollEvent struct {
 	Events uint32
 	Data   [8]byte // unaligned uintptr
 }
+
+// Utsname is the new_utsname structure filled in by uname(2).
+type Utsname struct {
+	Sysname    [65]byte
+	Nodename   [65]byte
+	Release    [65]byte
+	Version    [65]byte
+	Machine    [65]byte
+	Domainname [65]byte
+}
@@ -35,5 +35,6 @@ const (
 	SYS_EVENTFD2      = 290
 	SYS_EPOLL_CREATE1 = 291
 	SYS_PRCTL         = 157
+	SYS_UNAME         = 63
 	SYS_EPOLL_PWAIT2  = 441
 )
@@ -36,5 +36,6 @@ const (
 	SYS_EVENTFD2      = 19
 	SYS_EPOLL_CREATE1 = 20
 	SYS_PRCTL         = 167
+	SYS_UNAME         = 160
 	SYS_EPOLL_PWAIT2  = 441
 )
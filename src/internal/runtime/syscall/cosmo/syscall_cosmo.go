@@ -76,3 +76,8 @@ func Pread(fd int, p []byte, offset int64) (n int, errno uintptr) {
 	r1, _, e := Syscall6(SYS_PREAD64, uintptr(fd), uintptr(p0), uintptr(len(p)), uintptr(offset), 0, 0)
 	return int(r1), e
 }
+
+func Uname(buf *Utsname) (errno uintptr) {
+	_, _, e := Syscall6(SYS_UNAME, uintptr(unsafe.Pointer(buf)), 0, 0, 0, 0, 0)
+	return e
+}
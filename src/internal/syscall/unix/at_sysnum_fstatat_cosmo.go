@@ -2,11 +2,11 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-//go:build cosmo
+//go:build cosmo && arm64
 
 package unix
 
 import "syscall"
 
-// Cosmopolitan uses Linux syscall numbers; for amd64 NEWFSTATAT is the right call
-const fstatatTrap uintptr = syscall.SYS_NEWFSTATAT
+// Cosmopolitan uses Linux syscall numbers; for arm64 FSTATAT is the right call
+const fstatatTrap uintptr = syscall.SYS_FSTATAT
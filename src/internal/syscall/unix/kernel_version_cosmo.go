@@ -6,10 +6,74 @@
 
 package unix
 
-// KernelVersion returns the major and minor version of the kernel.
-// For Cosmopolitan, we report a reasonable Linux kernel version
-// since Cosmopolitan provides Linux syscall compatibility.
-func KernelVersion() (major int, minor int) {
-	// Report Linux 5.0 as a baseline for modern syscall support
-	return 5, 0
+import "internal/hostos"
+
+// A KernelFamily identifies the kind of host kernel a Cosmopolitan
+// binary is running on.
+type KernelFamily int
+
+const (
+	KernelUnknown KernelFamily = iota
+	KernelLinux
+	KernelFreeBSD
+	KernelOpenBSD
+	KernelNetBSD
+	KernelDarwin
+	KernelWindows
+)
+
+var kernelFamilies = [...]string{
+	KernelUnknown: "unknown",
+	KernelLinux:   "linux",
+	KernelFreeBSD: "freebsd",
+	KernelOpenBSD: "openbsd",
+	KernelNetBSD:  "netbsd",
+	KernelDarwin:  "darwin",
+	KernelWindows: "windows",
+}
+
+func (f KernelFamily) String() string {
+	if f < 0 || int(f) >= len(kernelFamilies) {
+		return kernelFamilies[KernelUnknown]
+	}
+	return kernelFamilies[f]
+}
+
+// kernelFamily maps a GOOS name from internal/hostos to a KernelFamily.
+func kernelFamily(goos string) KernelFamily {
+	for f, name := range kernelFamilies {
+		if name == goos {
+			return KernelFamily(f)
+		}
+	}
+	return KernelUnknown
+}
+
+// KernelVersion returns major and minor kernel version numbers
+// parsed from the host kernel's uname release, or (0, 0) if
+// the version can't be obtained or parsed.
+//
+// The numbers are those of whatever kernel is hosting the binary,
+// which need not be Linux; use KernelHost to find out which one.
+func KernelVersion() (major, minor int) {
+	return hostos.Version()
+}
+
+// KernelHost reports the family of the host kernel, as detected
+// by the runtime at startup.
+func KernelHost() KernelFamily {
+	return kernelFamily(hostos.Name())
+}
+
+// LinuxHost reports whether the host kernel is Linux.
+func LinuxHost() bool {
+	return KernelHost() == KernelLinux
+}
+
+// LinuxKernelVersionGE reports whether the host kernel is Linux and
+// its version is greater than or equal to the provided version.
+// Feature checks for Linux-only system calls should use it
+// instead of KernelVersionGE, whose answer is meaningless on BSD hosts.
+func LinuxKernelVersionGE(x, y int) bool {
+	return LinuxHost() && KernelVersionGE(x, y)
 }
@@ -0,0 +1,26 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package unix_test
+
+import (
+	"internal/syscall/unix"
+	"testing"
+)
+
+func TestKernelVersionCosmo(t *testing.T) {
+	host := unix.KernelHost()
+	major, minor := unix.KernelVersion()
+	t.Logf("Running on %v %d.%d", host, major, minor)
+
+	if host == unix.KernelUnknown {
+		t.Fatalf("KernelHost() = %v; want a known host kernel", host)
+	}
+	if major == 0 && minor == 0 {
+		t.Fatalf("KernelVersion() = 0.0; want the host kernel release")
+	}
+	if got, want := unix.LinuxKernelVersionGE(major, minor), host == unix.KernelLinux; got != want {
+		t.Errorf("LinuxKernelVersionGE(%d, %d) = %t; want %t", major, minor, got, want)
+	}
+}
@@ -5,6 +5,7 @@
 package unix
 
 import (
+	"internal/hostos"
 	"syscall"
 )
 
@@ -17,24 +18,27 @@ func KernelVersion() (major, minor int) {
 		return
 	}
 
-	var (
-		values    [2]int
-		value, vi int
-	)
+	var release [len(uname.Release)]byte
+	n := 0
 	for _, c := range uname.Release {
-		if '0' <= c && c <= '9' {
-			value = (value * 10) + int(c-'0')
-		} else {
-			// Note that we're assuming N.N.N here.
-			// If we see anything else, we are likely to mis-parse it.
-			values[vi] = value
-			vi++
-			if vi >= len(values) {
-				break
-			}
-			value = 0
+		if c == 0 {
+			break
 		}
+		release[n] = byte(c)
+		n++
 	}
+	return hostos.ParseVersion(string(release[:n]))
+}
+
+// LinuxHost reports whether the running kernel is Linux, which it
+// always is here. Code shared with the cosmo port uses it to gate
+// Linux-only system calls.
+func LinuxHost() bool {
+	return true
+}
 
-	return values[0], values[1]
+// LinuxKernelVersionGE is KernelVersionGE. It exists so that code
+// shared with the cosmo port can ask about the Linux kernel explicitly.
+func LinuxKernelVersionGE(x, y int) bool {
+	return KernelVersionGE(x, y)
 }
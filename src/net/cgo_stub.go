@@ -8,8 +8,9 @@
 // - on a Unix system without the cgo resolver functions
 //   (Darwin always provides the cgo functions, in cgo_unix_syscall.go)
 // - on wasip1, where cgo is never available
+// - on cosmo, which keeps the Go resolver even in cgo programs
 
-//go:build (netgo && unix) || (unix && !cgo && !darwin) || js || wasip1
+//go:build (netgo && unix) || (unix && !cgo && !darwin) || cosmo || js || wasip1
 
 package net
 
@@ -2,7 +2,7 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-//go:build !netgo && ((cgo && unix) || darwin)
+//go:build !netgo && ((cgo && unix && !cosmo) || darwin)
 
 package net
 
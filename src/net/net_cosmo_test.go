@@ -0,0 +1,122 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package net
+
+import (
+	"os"
+	"testing"
+)
+
+// TestCosmoListeners checks that stream and datagram sockets work on
+// whatever host is running the binary, not only on Linux hosts.
+func TestCosmoListeners(t *testing.T) {
+	goos, release := os.HostOS()
+	t.Logf("host %s %s", goos, release)
+
+	for _, network := range []string{"tcp4", "tcp6", "unix"} {
+		t.Run(network, func(t *testing.T) {
+			if !testableNetwork(network) {
+				t.Skipf("%s is not supported", network)
+			}
+			ln := newLocalListener(t, network)
+			defer ln.Close()
+
+			done := make(chan error, 1)
+			go func() {
+				c, err := ln.Accept()
+				if err != nil {
+					done <- err
+					return
+				}
+				defer c.Close()
+				var b [5]byte
+				if _, err := c.Read(b[:]); err != nil {
+					done <- err
+					return
+				}
+				_, err = c.Write(b[:])
+				done <- err
+			}()
+
+			c, err := Dial(network, ln.Addr().String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer c.Close()
+			if _, err := c.Write([]byte("hello")); err != nil {
+				t.Fatal(err)
+			}
+			var b [5]byte
+			if _, err := c.Read(b[:]); err != nil {
+				t.Fatal(err)
+			}
+			if string(b[:]) != "hello" {
+				t.Errorf("got %q; want %q", b[:], "hello")
+			}
+			if err := <-done; err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	for _, network := range []string{"udp4", "udp6", "unixgram"} {
+		t.Run(network, func(t *testing.T) {
+			if !testableNetwork(network) {
+				t.Skipf("%s is not supported", network)
+			}
+			c1 := newLocalPacketListener(t, network)
+			defer c1.Close()
+			c2 := newLocalPacketListener(t, network)
+			defer c2.Close()
+
+			if _, err := c2.WriteTo([]byte("hello"), c1.LocalAddr()); err != nil {
+				t.Fatal(err)
+			}
+			var b [5]byte
+			_, addr, err := c1.ReadFrom(b[:])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b[:]) != "hello" {
+				t.Errorf("got %q; want %q", b[:], "hello")
+			}
+			if addr.String() != c2.LocalAddr().String() {
+				t.Errorf("got message from %v; want %v", addr, c2.LocalAddr())
+			}
+		})
+	}
+}
+
+// TestCosmoInterfaces checks that the netlink interface tables work on
+// Linux hosts and fail cleanly elsewhere.
+func TestCosmoInterfaces(t *testing.T) {
+	goos, _ := os.HostOS()
+	ift, err := Interfaces()
+	if goos != "linux" {
+		// Other hosts have no netlink sockets.
+		if err == nil && len(ift) > 0 {
+			t.Logf("%s host reported %d interfaces", goos, len(ift))
+		}
+		return
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	var loopback bool
+	for _, ifi := range ift {
+		if ifi.Flags&FlagLoopback != 0 {
+			loopback = true
+		}
+		if _, err := ifi.Addrs(); err != nil {
+			t.Errorf("%s: %v", ifi.Name, err)
+		}
+	}
+	if !loopback {
+		t.Errorf("no loopback interface in %v", ift)
+	}
+	if _, err := InterfaceAddrs(); err != nil {
+		t.Error(err)
+	}
+}
@@ -2,7 +2,7 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-//go:build linux || (darwin && !ios) || dragonfly || freebsd || solaris || windows
+//go:build cosmo || linux || (darwin && !ios) || dragonfly || freebsd || solaris || windows
 
 package net
 
@@ -0,0 +1,15 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build cosmo
+
+package net
+
+import "internal/hostos"
+
+// sendfile(2) is only used on Linux hosts, where the
+// system call has the semantics internal/poll expects.
+func supportsSendfile() bool {
+	return hostos.Name() == "linux"
+}
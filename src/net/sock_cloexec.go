@@ -5,7 +5,7 @@
 // This file implements sysSocket for platforms that provide a fast path for
 // setting SetNonblock and CloseOnExec.
 
-//go:build dragonfly || freebsd || linux || netbsd || openbsd
+//go:build cosmo || dragonfly || freebsd || linux || netbsd || openbsd
 
 package net
 
@@ -2,6 +2,8 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+//go:build cosmo || linux
+
 package net
 
 import (
@@ -19,7 +21,7 @@ import (
 // See issue 5030 and 41470.
 func maxAckBacklog(n int) int {
 	size := 16
-	if unix.KernelVersionGE(4, 1) {
+	if unix.LinuxKernelVersionGE(4, 1) {
 		size = 32
 	}
 
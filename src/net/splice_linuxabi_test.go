@@ -2,12 +2,13 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-//go:build linux
+//go:build cosmo || linux
 
 package net
 
 import (
 	"internal/poll"
+	"internal/syscall/unix"
 	"io"
 	"os"
 	"strconv"
@@ -17,6 +18,9 @@ import (
 )
 
 func TestSplice(t *testing.T) {
+	if !unix.LinuxHost() {
+		t.Skip("splice(2) needs a Linux host")
+	}
 	t.Run("tcp-to-tcp", func(t *testing.T) { testSplice(t, "tcp", "tcp") })
 	if !testableNetwork("unixgram") {
 		t.Skip("skipping unix-to-tcp tests")
@@ -2,7 +2,7 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-//go:build aix || dragonfly || freebsd || illumos || linux || netbsd
+//go:build aix || cosmo || dragonfly || freebsd || illumos || linux || netbsd
 
 package net
 
@@ -280,7 +280,8 @@ func TestUnixConnLocalAndRemoteNames(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		switch runtime.GOOS {
+		// On cosmo, whether unnamed sockets autobind depends on the host.
+		switch goos, _ := os.HostOS(); goos {
 		case "android", "linux", "windows":
 			if laddr == "" {
 				laddr = "@" // autobind feature
@@ -336,7 +337,7 @@ func TestUnixgramConnLocalAndRemoteNames(t *testing.T) {
 			}
 		}()
 
-		switch runtime.GOOS {
+		switch goos, _ := os.HostOS(); goos {
 		case "android", "linux":
 			if laddr == "" {
 				laddr = "@" // autobind feature
@@ -0,0 +1,21 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package exec
+
+import "path/filepath"
+
+func init() {
+	findAlternate = findCOM
+}
+
+// findCOM finds "foo.com" when LookPath is asked for "foo", as
+// Cosmopolitan executables are conventionally named with a .com suffix.
+func findCOM(path, file string) (string, error) {
+	if filepath.Ext(file) != "" {
+		return path, findExecutable(path)
+	}
+	path += ".com"
+	return path, findExecutable(path)
+}
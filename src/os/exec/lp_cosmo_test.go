@@ -0,0 +1,36 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package exec_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestLookPathComSuffix(t *testing.T) {
+	// Not parallel: uses Setenv.
+
+	tmp := t.TempDir()
+	want := filepath.Join(tmp, "exec_me.com")
+	if err := os.WriteFile(want, []byte("#!/bin/sh\n"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", tmp)
+
+	for _, name := range []string{"exec_me", "exec_me.com"} {
+		path, err := exec.LookPath(name)
+		if err != nil {
+			t.Fatalf("LookPath(%q): %v", name, err)
+		}
+		if path != want {
+			t.Errorf("LookPath(%q) = %q; want %q", name, path, want)
+		}
+	}
+	if _, err := exec.LookPath("exec_me.exe"); err == nil {
+		t.Error(`LookPath("exec_me.exe") succeeded; want error`)
+	}
+}
@@ -19,6 +19,11 @@ import (
 // ErrNotFound is the error resulting if a path search failed to find an executable file.
 var ErrNotFound = errors.New("executable file not found in $PATH")
 
+// findAlternate, if not nil, is called by LookPath when file is not
+// an executable at path in a PATH directory, to look for another name
+// for it. It is set in lp_cosmo.go.
+var findAlternate func(path, file string) (string, error)
+
 func findExecutable(file string) error {
 	d, err := os.Stat(file)
 	if err != nil {
@@ -72,7 +77,11 @@ func LookPath(file string) (string, error) {
 			dir = "."
 		}
 		path := filepath.Join(dir, file)
-		if err := findExecutable(path); err == nil {
+		err := findExecutable(path)
+		if err != nil && findAlternate != nil {
+			path, err = findAlternate(path, file)
+		}
+		if err == nil {
 			if !filepath.IsAbs(path) {
 				if execerrdot.Value() != "0" {
 					return path, &Error{file, ErrDot}
@@ -0,0 +1,32 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"internal/stringslite"
+	_ "unsafe" // for linkname
+)
+
+//go:linkname executablePath
+var executablePath string // set by ../runtime/os_cosmo.go
+
+func executable() (string, error) {
+	// When started by the APE shell launcher, /proc/self/exe names
+	// the temporary ELF extracted from the .com file, not the file
+	// the user ran. The launcher tells the runtime the real path,
+	// and the runtime only believes it if the launcher's process ID
+	// is our own.
+	if ep := executablePath; ep != "" {
+		return ep, nil
+	}
+
+	// Otherwise the ELF payload was executed directly, for example
+	// through binfmt_misc or an ape loader, and the kernel knows.
+	path, err := Readlink("/proc/self/exe")
+
+	// When the executable has been deleted then Readlink returns a
+	// path appended with " (deleted)".
+	return stringslite.TrimSuffix(path, " (deleted)"), err
+}
@@ -0,0 +1,120 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os_test
+
+import (
+	"bytes"
+	"fmt"
+	"internal/testenv"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestExecutableAPEProgram checks that os.Executable believes the
+// APE_PROGRAM variable of the APE shell launcher only when APE_PID
+// names the running process.
+func TestExecutableAPEProgram(t *testing.T) {
+	const helperEnvVar = "OSTEST_OUTPUT_APE_PROGRAM"
+
+	if os.Getenv(helperEnvVar) != "" {
+		ep, err := os.Executable()
+		if err != nil {
+			fmt.Fprint(os.Stderr, "ERROR: ", err)
+			os.Exit(1)
+		}
+		// The variables are consumed by the runtime.
+		fmt.Fprintf(os.Stderr, "%s\n%s%s", ep, os.Getenv("APE_PROGRAM"), os.Getenv("APE_PID"))
+		os.Exit(0)
+	}
+
+	if goos, _ := os.HostOS(); goos != "linux" {
+		t.Skipf("skipping on %s host: needs /proc/self/exe", goos)
+	}
+	t.Parallel()
+
+	exe := testenv.Executable(t)
+	data, err := os.ReadFile(exe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(data, []byte("MZqFpD='")) {
+		t.Skip("test binary is not an APE program")
+	}
+
+	// Extract the ELF payload the way the launcher does, so that it
+	// can be started without the launcher setting the variables.
+	m := regexp.MustCompile(`tail -c \+([0-9]+) "\$o"`).FindSubmatch(data)
+	if m == nil {
+		t.Fatal("no payload extraction in the APE launcher")
+	}
+	off, err := strconv.Atoi(string(m[1]))
+	if err != nil || off < 1 || off > len(data) {
+		t.Fatalf("bad payload offset %q", m[1])
+	}
+	payload := filepath.Join(t.TempDir(), "payload")
+	if err := os.WriteFile(payload, data[off-1:], 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	const forged = "/nonexistent/forged.com"
+	run := func(name string, args []string, env ...string) (ep, leftover string) {
+		t.Helper()
+		cmd := testenv.Command(t, name, append(args, "-test.run=^"+t.Name()+"$")...)
+		cmd.Env = append(cmd.Environ(), helperEnvVar+"=1")
+		cmd.Env = append(cmd.Env, env...)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("%v: %v\n%s", cmd, err, out)
+		}
+		ep, leftover, _ = strings.Cut(string(out), "\n")
+		return ep, leftover
+	}
+
+	tests := []struct {
+		desc string
+		name string
+		args []string
+		env  []string
+		want string
+	}{
+		{
+			desc: "launcher",
+			name: exe,
+			env:  []string{"APE_PROGRAM=" + forged, "APE_PID=1"},
+			want: exe,
+		},
+		{
+			desc: "inherited variables",
+			name: payload,
+			env:  []string{"APE_PROGRAM=" + forged, "APE_PID=1"},
+			want: payload,
+		},
+		{
+			desc: "no APE_PID",
+			name: payload,
+			env:  []string{"APE_PROGRAM=" + forged},
+			want: payload,
+		},
+		{
+			desc: "matching APE_PID",
+			name: "/bin/sh",
+			args: []string{"-c", `APE_PROGRAM=` + forged + ` APE_PID=$$ exec "$0" "$@"`, payload},
+			want: forged,
+		},
+	}
+	for _, tt := range tests {
+		ep, leftover := run(tt.name, tt.args, tt.env...)
+		if ep != tt.want {
+			t.Errorf("%s: Executable() = %q; want %q", tt.desc, ep, tt.want)
+		}
+		if leftover != "" {
+			t.Errorf("%s: launcher variables left in the environment: %q", tt.desc, leftover)
+		}
+	}
+}
@@ -501,13 +501,15 @@ func TempDir() string {
 // On Darwin, it returns $HOME/Library/Caches.
 // On Windows, it returns %LocalAppData%.
 // On Plan 9, it returns $home/lib/cache.
+// On cosmo, it follows the conventions of the host operating system
+// the program is running on.
 //
 // If the location cannot be determined (for example, $HOME is not defined) or
 // the path in $XDG_CACHE_HOME is relative, then it will return an error.
 func UserCacheDir() (string, error) {
 	var dir string
 
-	switch runtime.GOOS {
+	switch userDirGOOS() {
 	case "windows":
 		dir = Getenv("LocalAppData")
 		if dir == "" {
@@ -554,13 +556,15 @@ func UserCacheDir() (string, error) {
 // On Darwin, it returns $HOME/Library/Application Support.
 // On Windows, it returns %AppData%.
 // On Plan 9, it returns $home/lib.
+// On cosmo, it follows the conventions of the host operating system
+// the program is running on.
 //
 // If the location cannot be determined (for example, $HOME is not defined) or
 // the path in $XDG_CONFIG_HOME is relative, then it will return an error.
 func UserConfigDir() (string, error) {
 	var dir string
 
-	switch runtime.GOOS {
+	switch userDirGOOS() {
 	case "windows":
 		dir = Getenv("AppData")
 		if dir == "" {
@@ -602,12 +606,14 @@ func UserConfigDir() (string, error) {
 // On Unix, including macOS, it returns the $HOME environment variable.
 // On Windows, it returns %USERPROFILE%.
 // On Plan 9, it returns the $home environment variable.
+// On cosmo, it follows the conventions of the host operating system
+// the program is running on.
 //
 // If the expected variable is not set in the environment, UserHomeDir
 // returns either a platform-specific default value or a non-nil error.
 func UserHomeDir() (string, error) {
 	env, enverr := "HOME", "$HOME"
-	switch runtime.GOOS {
+	switch userDirGOOS() {
 	case "windows":
 		env, enverr = "USERPROFILE", "%userprofile%"
 	case "plan9":
@@ -617,7 +623,7 @@ func UserHomeDir() (string, error) {
 		return v, nil
 	}
 	// On some operating systems the home directory is not always defined.
-	switch runtime.GOOS {
+	switch userDirGOOS() {
 	case "android":
 		return "/sdcard", nil
 	case "ios":
@@ -0,0 +1,20 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import "internal/hostos"
+
+// HostOS reports the operating system hosting the program as a GOOS
+// value, such as "linux", "freebsd", "darwin" or "windows", along with
+// the kernel release reported by uname(2), such as "6.1.0-13-amd64".
+// Either result is empty if it is not known.
+//
+// With GOOS=cosmo one executable runs on several operating systems
+// and runtime.GOOS is always "cosmo", so HostOS is the way to tell
+// them apart. On other ports the host is runtime.GOOS, and the
+// release is empty.
+func HostOS() (goos, release string) {
+	return hostos.Name(), hostos.Release()
+}
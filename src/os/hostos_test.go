@@ -0,0 +1,25 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os_test
+
+import (
+	"os"
+	"runtime"
+	"testing"
+)
+
+func TestHostOS(t *testing.T) {
+	goos, release := os.HostOS()
+	t.Logf("HostOS() = %q, %q", goos, release)
+	if runtime.GOOS == "cosmo" {
+		if goos == "cosmo" {
+			t.Errorf("HostOS() reports %q, want the host operating system", goos)
+		}
+		return
+	}
+	if goos != runtime.GOOS {
+		t.Errorf("HostOS() = %q; want runtime.GOOS %q", goos, runtime.GOOS)
+	}
+}
@@ -2,6 +2,8 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+//go:build cosmo || linux
+
 // Support for pidfd was added during the course of a few Linux releases:
 //  v5.1: pidfd_send_signal syscall;
 //  v5.2: CLONE_PIDFD flag for clone syscall;
@@ -148,13 +150,18 @@ var checkPidfdOnce = sync.OnceValue(checkPidfd)
 // consists of pidfd_open and pidfd_send_signal syscalls, waitid syscall with
 // idtype of P_PIDFD, and clone(CLONE_PIDFD).
 //
-// Reasons for non-working pidfd syscalls include an older kernel and an
-// execution environment in which the above system calls are restricted by
-// seccomp or a similar technology.
+// Reasons for non-working pidfd syscalls include an older kernel, a
+// non-Linux host for cosmo binaries, and an execution environment in which
+// the above system calls are restricted by seccomp or a similar technology.
 func checkPidfd() error {
+	if !unix.LinuxHost() {
+		return NewSyscallError("pidfd_open", syscall.ENOSYS)
+	}
+
 	// In Android version < 12, pidfd-related system calls are not allowed
 	// by seccomp and trigger the SIGSYS signal. See issue #69065.
-	if runtime.GOOS == "android" {
+	// A cosmo binary can't tell whether its Linux host is Android.
+	if runtime.GOOS == "android" || runtime.GOOS == "cosmo" {
 		ignoreSIGSYS()
 		defer restoreSIGSYS()
 	}
@@ -0,0 +1,132 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os_test
+
+import (
+	"bytes"
+	"internal/poll"
+	"internal/syscall/unix"
+	"io"
+	"math/rand"
+	"net"
+	. "os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCopyFileRangeCosmo(t *testing.T) {
+	data := make([]byte, 1<<20)
+	rand.Read(data)
+	dir := t.TempDir()
+	src, err := Create(filepath.Join(dir, "src"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Close()
+	if _, err := src.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	dst, err := Create(filepath.Join(dir, "dst"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Close()
+
+	var called, handled bool
+	orig := *PollCopyFileRangeP
+	t.Cleanup(func() { *PollCopyFileRangeP = orig })
+	*PollCopyFileRangeP = func(dst, src *poll.FD, remain int64) (int64, bool, error) {
+		called = true
+		n, h, err := orig(dst, src, remain)
+		handled = h
+		return n, h, err
+	}
+
+	n, err := io.Copy(dst, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len(data)) {
+		t.Fatalf("copied %d bytes; want %d", n, len(data))
+	}
+	if !called {
+		t.Fatal("copy_file_range hook was not called")
+	}
+	if want := unix.LinuxKernelVersionGE(5, 3); handled != want {
+		major, minor := unix.KernelVersion()
+		t.Errorf("copy_file_range handled = %t on %v %d.%d; want %t", handled, unix.KernelHost(), major, minor, want)
+	}
+	got, err := ReadFile(dst.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatal("copied data does not match the source")
+	}
+}
+
+func TestSpliceFileCosmo(t *testing.T) {
+	data := make([]byte, 32769)
+	rand.Read(data)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		client, err := net.Dial("tcp", ln.Addr().String())
+		if err != nil {
+			return
+		}
+		client.Write(data)
+		client.Close()
+	}()
+	server, err := ln.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	dst, err := Create(filepath.Join(t.TempDir(), "dst"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Close()
+
+	var called, handled bool
+	orig := *PollSpliceFile
+	t.Cleanup(func() { *PollSpliceFile = orig })
+	*PollSpliceFile = func(dst, src *poll.FD, remain int64) (int64, bool, error) {
+		called = true
+		n, h, err := orig(dst, src, remain)
+		handled = h
+		return n, h, err
+	}
+
+	n, err := io.Copy(dst, server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len(data)) {
+		t.Fatalf("copied %d bytes; want %d", n, len(data))
+	}
+	if !called {
+		t.Fatal("splice hook was not called")
+	}
+	if want := unix.KernelHost() == unix.KernelLinux; handled != want {
+		t.Errorf("splice handled = %t on %v; want %t", handled, unix.KernelHost(), want)
+	}
+	got, err := ReadFile(dst.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatal("spliced data does not match the source")
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os_test
+
+import (
+	"internal/syscall/unix"
+	. "os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+func TestRootOpenat2Cosmo(t *testing.T) {
+	dir := t.TempDir()
+	if err := MkdirAll(filepath.Join(dir, "root", "a"), 0o777); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFile(filepath.Join(dir, "root", "a", "b"), nil, 0o666); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFile(filepath.Join(dir, "outside"), nil, 0o666); err != nil {
+		t.Fatal(err)
+	}
+	for link, target := range map[string]string{
+		"link":     "a/b",
+		"escape":   "../outside",
+		"absolute": filepath.Join(dir, "outside"),
+	} {
+		if err := Symlink(target, filepath.Join(dir, "root", link)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r, err := OpenRoot(filepath.Join(dir, "root"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	supported := unix.LinuxKernelVersionGE(5, 6)
+	for _, test := range []struct {
+		name string
+		ok   bool
+	}{
+		{"a/b", true},
+		{"a/../a/b", true},
+		{"link", true},
+		{"a", true},
+		{"missing", false},
+		{"../outside", false},
+		{"escape", false},
+		{"absolute", false},
+		{filepath.Join(dir, "outside"), false},
+	} {
+		fd, ok := RootOpenat2(r, test.name, O_RDONLY, 0)
+		if ok {
+			syscall.Close(fd)
+		}
+		if want := supported && test.ok; ok != want {
+			t.Errorf("rootOpenat2(%q) = %v; want %v", test.name, ok, want)
+		}
+		// Whichever path resolves the name, Root.Open must agree
+		// on whether it is inside the root.
+		f, err := r.Open(test.name)
+		if err == nil {
+			f.Close()
+		}
+		if (err == nil) != test.ok {
+			t.Errorf("Root.Open(%q) = %v; want success %v", test.name, err, test.ok)
+		}
+	}
+}
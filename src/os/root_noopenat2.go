@@ -0,0 +1,13 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build (unix || wasip1) && !cosmo
+
+package os
+
+// rootOpenat2 opens name in r in a single system call where the
+// platform can do so safely. It reports whether it opened the file.
+func rootOpenat2(r *Root, name string, flag int, perm FileMode) (fd int, ok bool) {
+	return -1, false
+}
@@ -0,0 +1,66 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build cosmo
+
+package os
+
+import (
+	"internal/syscall/unix"
+	"sync"
+	"sync/atomic"
+	"syscall"
+)
+
+// On Linux hosts with openat2 (Linux 5.6 and later), the kernel can
+// resolve a whole path inside a Root in one system call:
+// RESOLVE_BENEATH makes it fail rather than leave the directory,
+// and RESOLVE_NO_MAGICLINKS rejects /proc-style links.
+//
+// The fast path is only used when it succeeds. On any error,
+// including an escape attempt, the caller falls back to walking the
+// path one component at a time, which reports the error the way
+// every other platform does.
+
+var openat2Supported = sync.OnceValue(func() bool {
+	return unix.LinuxKernelVersionGE(5, 6)
+})
+
+// openat2Disabled is set when openat2 turns out to be unusable
+// despite the kernel version, typically because a seccomp filter
+// makes it fail with ENOSYS.
+var openat2Disabled atomic.Bool
+
+// rootOpenat2 opens name in r with openat2.
+// It reports whether it opened the file.
+func rootOpenat2(r *Root, name string, flag int, perm FileMode) (fd int, ok bool) {
+	if !openat2Supported() || openat2Disabled.Load() {
+		return -1, false
+	}
+	if err := r.root.incref(); err != nil {
+		return -1, false
+	}
+	defer r.root.decref()
+
+	how := unix.OpenHow{
+		Flags:   uint64(flag | syscall.O_CLOEXEC),
+		Resolve: unix.RESOLVE_BENEATH | unix.RESOLVE_NO_MAGICLINKS,
+	}
+	if flag&O_CREATE != 0 {
+		// open_how.mode must be zero unless a file may be created.
+		how.Mode = uint64(syscallMode(perm))
+	}
+	var err error
+	ignoringEINTR(func() error {
+		fd, err = unix.Openat2(r.root.fd, name, &how)
+		return err
+	})
+	if err == syscall.ENOSYS {
+		openat2Disabled.Store(true)
+	}
+	if err != nil {
+		return -1, false
+	}
+	return fd, true
+}
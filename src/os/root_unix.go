@@ -62,6 +62,9 @@ func newRoot(fd int, name string) (*Root, error) {
 
 // openRootInRoot is Root.OpenRoot.
 func openRootInRoot(r *Root, name string) (*Root, error) {
+	if fd, ok := rootOpenat2(r, name, 0, 0); ok {
+		return newRoot(fd, joinPath(r.Name(), name))
+	}
 	fd, err := doInRoot(r, name, nil, func(parent int, name string) (fd int, err error) {
 		ignoringEINTR(func() error {
 			fd, err = unix.Openat(parent, name, syscall.O_NOFOLLOW|syscall.O_CLOEXEC, 0)
@@ -80,6 +83,9 @@ func openRootInRoot(r *Root, name string) (*Root, error) {
 
 // rootOpenFileNolog is Root.OpenFile.
 func rootOpenFileNolog(root *Root, name string, flag int, perm FileMode) (*File, error) {
+	if fd, ok := rootOpenat2(root, name, flag, perm); ok {
+		return newFile(fd, joinPath(root.Name(), name), kindOpenFile, unix.HasNonblockFlag(flag)), nil
+	}
 	fd, err := doInRoot(root, name, nil, func(parent int, name string) (fd int, err error) {
 		ignoringEINTR(func() error {
 			fd, err = unix.Openat(parent, name, syscall.O_NOFOLLOW|syscall.O_CLOEXEC|flag, uint32(perm))
@@ -0,0 +1,23 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os
+
+import (
+	"internal/hostos"
+	"runtime"
+)
+
+// userDirGOOS returns the GOOS whose conventions UserHomeDir,
+// UserCacheDir and UserConfigDir follow. A cosmo binary runs
+// unchanged on several operating systems, so it asks which one
+// is hosting it rather than using the compile-time GOOS.
+//
+// It is a variable so that tests can pretend to run elsewhere.
+var userDirGOOS = func() string {
+	if h := hostos.Name(); h != "" {
+		return h
+	}
+	return runtime.GOOS
+}
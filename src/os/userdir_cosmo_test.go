@@ -0,0 +1,52 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package os_test
+
+import (
+	"internal/hostos"
+	. "os"
+	"testing"
+)
+
+func TestUserDirsFollowHostCosmo(t *testing.T) {
+	if got, want := (*UserDirGOOSP)(), hostos.Name(); got != want {
+		t.Errorf("user directories follow %q; want the host, %q", got, want)
+	}
+
+	t.Setenv("HOME", "/home/gopher")
+	t.Setenv("XDG_CACHE_HOME", "/xdg/cache")
+	t.Setenv("XDG_CONFIG_HOME", "/xdg/config")
+	t.Setenv("USERPROFILE", `C:\Users\gopher`)
+	t.Setenv("LocalAppData", `C:\Users\gopher\AppData\Local`)
+	t.Setenv("AppData", `C:\Users\gopher\AppData\Roaming`)
+
+	orig := *UserDirGOOSP
+	t.Cleanup(func() { *UserDirGOOSP = orig })
+	for _, test := range []struct {
+		host                string
+		home, cache, config string
+	}{
+		{"linux", "/home/gopher", "/xdg/cache", "/xdg/config"},
+		{"freebsd", "/home/gopher", "/xdg/cache", "/xdg/config"},
+		{"darwin", "/home/gopher", "/home/gopher/Library/Caches", "/home/gopher/Library/Application Support"},
+		{"windows", `C:\Users\gopher`, `C:\Users\gopher\AppData\Local`, `C:\Users\gopher\AppData\Roaming`},
+	} {
+		*UserDirGOOSP = func() string { return test.host }
+		for _, f := range []struct {
+			name string
+			fn   func() (string, error)
+			want string
+		}{
+			{"UserHomeDir", UserHomeDir, test.home},
+			{"UserCacheDir", UserCacheDir, test.cache},
+			{"UserConfigDir", UserConfigDir, test.config},
+		} {
+			got, err := f.fn()
+			if err != nil || got != f.want {
+				t.Errorf("on %s: %s() = %q, %v; want %q", test.host, f.name, got, err, f.want)
+			}
+		}
+	}
+}
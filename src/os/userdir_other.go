@@ -0,0 +1,15 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !cosmo
+
+package os
+
+import "runtime"
+
+// userDirGOOS returns the GOOS whose conventions UserHomeDir,
+// UserCacheDir and UserConfigDir follow.
+func userDirGOOS() string {
+	return runtime.GOOS
+}
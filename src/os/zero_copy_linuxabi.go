@@ -2,10 +2,13 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+//go:build cosmo || linux
+
 package os
 
 import (
 	"internal/poll"
+	"internal/syscall/unix"
 	"io"
 	"syscall"
 )
@@ -16,6 +19,11 @@ var (
 )
 
 func (f *File) writeTo(w io.Writer) (written int64, handled bool, err error) {
+	// sendfile(2) with a nil offset has Linux semantics;
+	// leave other hosts to the generic copy.
+	if !unix.LinuxHost() {
+		return
+	}
 	pfd, network := getPollFDAndNetwork(w)
 	// TODO(panjf2000): same as File.spliceToFile.
 	if pfd == nil || !pfd.IsStream || !isUnixOrTCP(string(network)) {
@@ -9,8 +9,10 @@ package runtime
 import (
 	"internal/abi"
 	"internal/goarch"
+	"internal/gocosmo"
 	"internal/runtime/atomic"
 	"internal/runtime/syscall/cosmo"
+	"internal/stringslite"
 	"unsafe"
 )
 
@@ -248,8 +250,49 @@ func sysauxv(auxv []uintptr) (pairs int) {
 	return i / 2
 }
 
+// hostUname describes the kernel hosting the program. A cosmo binary
+// runs unchanged on several operating systems, so osinit asks once and
+// internal/hostos reports the answer to the rest of the standard library.
+var hostUname cosmo.Utsname
+
+//go:linkname hostSysname internal/hostos.sysname
+var hostSysname string
+
+//go:linkname hostRelease internal/hostos.release
+var hostRelease string
+
 func osinit() {
 	numCPUStartup = getCPUCount()
+	if cosmo.Uname(&hostUname) == 0 {
+		hostSysname = gostringnocopy(&hostUname.Sysname[0])
+		hostRelease = gostringnocopy(&hostUname.Release[0])
+	}
+	if !hostSupported(hostSysname) {
+		print("runtime: this program was not built to run on ", hostSysname, " (see GOCOSMO)\n")
+		exit(2)
+	}
+}
+
+// hostSupported reports whether GOCOSMO included the kernel named
+// sysname when the program was built. A kernel the port does not
+// recognize, or one that uname could not name, gets the benefit of
+// the doubt.
+func hostSupported(sysname string) bool {
+	switch sysname {
+	case "Linux":
+		return gocosmo.Linux
+	case "FreeBSD":
+		return gocosmo.FreeBSD
+	case "OpenBSD":
+		return gocosmo.OpenBSD
+	case "NetBSD":
+		return gocosmo.NetBSD
+	case "Darwin", "XNU":
+		return gocosmo.Darwin
+	case "Windows", "Windows_NT":
+		return gocosmo.Windows
+	}
+	return true
 }
 
 var urandom_dev = []byte("/dev/urandom\x00")
@@ -261,8 +304,44 @@ func readRandom(r []byte) int {
 	return int(n)
 }
 
+// apeProgramEnv and apePIDEnv are exported by the APE shell launcher.
+// APE_PROGRAM holds the absolute path of the .com file that was run,
+// which the kernel never sees because it executes an extracted copy of
+// the ELF payload. APE_PID holds the launcher's process ID, which the
+// program keeps across exec. Variables inherited from another process,
+// or set by hand, name some other process and are ignored.
+const (
+	apeProgramEnv = "APE_PROGRAM="
+	apePIDEnv     = "APE_PID="
+)
+
+//go:linkname executablePath os.executablePath
+var executablePath string
+
 func goenvs() {
 	goenvs_unix()
+
+	// Consume the launcher's variables so that they are not inherited
+	// by children that are not themselves APE programs.
+	var program, pid string
+	n := 0
+	for _, e := range envs {
+		switch {
+		case stringslite.HasPrefix(e, apeProgramEnv):
+			program = e[len(apeProgramEnv):]
+		case stringslite.HasPrefix(e, apePIDEnv):
+			pid = e[len(apePIDEnv):]
+		default:
+			envs[n] = e
+			n++
+		}
+	}
+	envs = envs[:n]
+
+	var buf [20]byte
+	if program != "" && pid == string(itoa(buf[:], uint64(getpid()))) {
+		executablePath = program
+	}
 }
 
 // Called to do synchronous initialization of Go code built with
@@ -499,8 +578,164 @@ type perThreadSyscallArgs struct {
 	r2   uintptr
 }
 
+// perThreadSyscall is the system call to execute for the ongoing
+// doAllThreadsSyscall.
+//
+// perThreadSyscall may only be written while mp.needPerThreadSyscall == 0 on
+// all Ms.
 var perThreadSyscall perThreadSyscallArgs
 
+// syscall_runtime_doAllThreadsSyscall and executes a specified system call on
+// all Ms.
+//
+// The system call is expected to succeed and return the same value on every
+// thread. If any threads do not match, the runtime throws.
+//
+//go:linkname syscall_runtime_doAllThreadsSyscall syscall.runtime_doAllThreadsSyscall
+//go:uintptrescapes
+func syscall_runtime_doAllThreadsSyscall(trap, a1, a2, a3, a4, a5, a6 uintptr) (r1, r2, err uintptr) {
+	if iscgo {
+		// In cgo, we are not aware of threads created in C, so this approach will not work.
+		panic("doAllThreadsSyscall not supported with cgo enabled")
+	}
+
+	// STW to guarantee that user goroutines see an atomic change to thread
+	// state. Without STW, goroutines could migrate Ms while change is in
+	// progress and e.g., see state old -> new -> old -> new.
+	//
+	// N.B. Internally, this function does not depend on STW to
+	// successfully change every thread. It is only needed for user
+	// expectations, per above.
+	stw := stopTheWorld(stwAllThreadsSyscall)
+
+	// This function depends on several properties:
+	//
+	// 1. All OS threads that already exist are associated with an M in
+	//    allm. i.e., we won't miss any pre-existing threads.
+	// 2. All Ms listed in allm will eventually have an OS thread exist.
+	//    i.e., they will set procid and be able to receive signals.
+	// 3. OS threads created after we read allm will clone from a thread
+	//    that has executed the system call. i.e., they inherit the
+	//    modified state.
+	//
+	// We achieve these through different mechanisms:
+	//
+	// 1. Addition of new Ms to allm in allocm happens before clone of its
+	//    OS thread later in newm.
+	// 2. newm does acquirem to avoid being preempted, ensuring that new Ms
+	//    created in allocm will eventually reach OS thread clone later in
+	//    newm.
+	// 3. We take allocmLock for write here to prevent allocation of new Ms
+	//    while this function runs. Per (1), this prevents clone of OS
+	//    threads that are not yet in allm.
+	allocmLock.lock()
+
+	// Disable preemption, preventing us from changing Ms, as we handle
+	// this M specially.
+	//
+	// N.B. STW and lock() above do this as well, this is added for extra
+	// clarity.
+	acquirem()
+
+	// N.B. allocmLock also prevents concurrent execution of this function,
+	// serializing use of perThreadSyscall, mp.needPerThreadSyscall, and
+	// ensuring all threads execute system calls from multiple calls in the
+	// same order.
+
+	r1, r2, errno := cosmo.Syscall6(trap, a1, a2, a3, a4, a5, a6)
+	if errno != 0 {
+		releasem(getg().m)
+		allocmLock.unlock()
+		startTheWorld(stw)
+		return r1, r2, errno
+	}
+
+	perThreadSyscall = perThreadSyscallArgs{
+		trap: trap,
+		a1:   a1,
+		a2:   a2,
+		a3:   a3,
+		a4:   a4,
+		a5:   a5,
+		a6:   a6,
+		r1:   r1,
+		r2:   r2,
+	}
+
+	// Wait for all threads to start.
+	//
+	// As described above, some Ms have been added to allm prior to
+	// allocmLock, but not yet completed OS clone and set procid.
+	//
+	// At minimum we must wait for a thread to set procid before we can
+	// send it a signal.
+	//
+	// We take this one step further and wait for all threads to start
+	// before sending any signals. This prevents system calls from getting
+	// applied twice: once in the parent and once in the child, like so:
+	//
+	//          A                     B                  C
+	//                         add C to allm
+	// doAllThreadsSyscall
+	//   allocmLock.lock()
+	//   signal B
+	//                         <receive signal>
+	//                         execute syscall
+	//                         <signal return>
+	//                         clone C
+	//                                             <thread start>
+	//                                             set procid
+	//   signal C
+	//                                             <receive signal>
+	//                                             execute syscall
+	//                                             <signal return>
+	//
+	// In this case, thread C inherited the syscall-modified state from
+	// thread B and did not need to execute the syscall, but did anyway
+	// because doAllThreadsSyscall could not be sure whether it was
+	// required.
+	//
+	// Some system calls may not be idempotent, so we ensure each thread
+	// executes the system call exactly once.
+	for mp := allm; mp != nil; mp = mp.alllink {
+		for atomic.Load64(&mp.procid) == 0 {
+			// Thread is starting.
+			osyield()
+		}
+	}
+
+	// Signal every other thread, where they will execute perThreadSyscall
+	// from the signal handler.
+	gp := getg()
+	tid := gp.m.procid
+	for mp := allm; mp != nil; mp = mp.alllink {
+		if atomic.Load64(&mp.procid) == tid {
+			// Our thread already performed the syscall.
+			continue
+		}
+		mp.needPerThreadSyscall.Store(1)
+		signalM(mp, sigPerThreadSyscall)
+	}
+
+	// Wait for all threads to complete.
+	for mp := allm; mp != nil; mp = mp.alllink {
+		if mp.procid == tid {
+			continue
+		}
+		for mp.needPerThreadSyscall.Load() != 0 {
+			osyield()
+		}
+	}
+
+	perThreadSyscall = perThreadSyscallArgs{}
+
+	releasem(getg().m)
+	allocmLock.unlock()
+	startTheWorld(stw)
+
+	return r1, r2, errno
+}
+
 //go:nosplit
 func runPerThreadSyscall() {
 	gp := getg()
@@ -510,9 +745,6 @@ func runPerThreadSyscall() {
 
 	args := perThreadSyscall
 	r1, r2, errno := cosmo.Syscall6(args.trap, args.a1, args.a2, args.a3, args.a4, args.a5, args.a6)
-	if GOARCH == "ppc64" || GOARCH == "ppc64le" {
-		r2 = 0
-	}
 	if errno != 0 || r1 != args.r1 || r2 != args.r2 {
 		print("trap:", args.trap, ", a123456=[", args.a1, ",", args.a2, ",", args.a3, ",", args.a4, ",", args.a5, ",", args.a6, "]\n")
 		print("results: got {r1=", r1, ",r2=", r2, ",errno=", errno, "}, want {r1=", args.r1, ",r2=", args.r2, ",errno=0}\n")
@@ -8,6 +8,7 @@ package pprof
 
 import (
 	"fmt"
+	"internal/hostos"
 	"io"
 	"runtime"
 	"syscall"
@@ -16,7 +17,8 @@ import (
 // Adds MaxRSS to platforms that are supported.
 func addMaxRSS(w io.Writer) {
 	var rssToBytes uintptr
-	switch runtime.GOOS {
+	// For cosmo, the unit is that of the host kernel.
+	switch hostos.Name() {
 	case "aix", "android", "dragonfly", "freebsd", "linux", "netbsd", "openbsd":
 		rssToBytes = 1024
 	case "darwin", "ios":
@@ -24,6 +26,9 @@ func addMaxRSS(w io.Writer) {
 	case "illumos", "solaris":
 		rssToBytes = uintptr(syscall.Getpagesize())
 	default:
+		if runtime.GOOS == "cosmo" {
+			return
+		}
 		panic("unsupported OS")
 	}
 
@@ -5,6 +5,6 @@
 // This package holds the race detector .syso for
 // amd64 architectures with GOAMD64>=v3.
 
-//go:build amd64 && linux && amd64.v3
+//go:build amd64 && (linux || cosmo) && amd64.v3
 
 package amd64v3
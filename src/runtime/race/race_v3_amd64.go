@@ -2,7 +2,7 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-//go:build linux && amd64.v3
+//go:build (linux || cosmo) && amd64.v3
 
 package race
 
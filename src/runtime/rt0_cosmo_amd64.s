@@ -6,8 +6,38 @@
 
 #include "textflag.h"
 
+// No dynamic loader runs for a cosmo program, whether the kernel, the
+// APE shell script or the ape loader starts it, so a position
+// independent executable applies its own R_X86_64_RELATIVE relocations
+// before anything reads a pointer from its data. The linker sets
+// runtime·rela and runtime·erela around the relocations; the range is
+// empty unless the program is a PIE.
 TEXT _rt0_amd64_cosmo(SB),NOSPLIT,$-8
+	// The load bias is the distance between where cosmoLinkAddr is
+	// and where it was linked, which is also its unrelocated value.
+	LEAQ	runtime·cosmoLinkAddr(SB), BX
+	SUBQ	(BX), BX
+	JEQ	done
+	LEAQ	runtime·rela(SB), SI
+	LEAQ	runtime·erela(SB), DI
+loop:
+	CMPQ	SI, DI
+	JAE	done
+	CMPL	8(SI), $8	// R_X86_64_RELATIVE
+	JNE	next
+	MOVQ	0(SI), CX	// r_offset
+	MOVQ	16(SI), DX	// r_addend
+	ADDQ	BX, CX
+	ADDQ	BX, DX
+	MOVQ	DX, 0(CX)
+next:
+	ADDQ	$24, SI
+	JMP	loop
+done:
 	JMP	_rt0_amd64(SB)
 
 TEXT _rt0_amd64_cosmo_lib(SB),NOSPLIT,$0
 	JMP	_rt0_amd64_lib(SB)
+
+DATA runtime·cosmoLinkAddr(SB)/8, $runtime·cosmoLinkAddr(SB)
+GLOBL runtime·cosmoLinkAddr(SB), NOPTR, $8
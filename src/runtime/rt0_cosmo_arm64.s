@@ -6,19 +6,40 @@
 
 #include "textflag.h"
 
+// No dynamic loader runs for a cosmo program, whether the kernel, the
+// APE shell script or the ape loader starts it, so a position
+// independent executable applies its own R_AARCH64_RELATIVE
+// relocations before anything reads a pointer from its data. The
+// linker sets runtime·rela and runtime·erela around the relocations;
+// the range is empty unless the program is a PIE.
 TEXT _rt0_arm64_cosmo(SB),NOSPLIT|NOFRAME,$0
-	MOV	0(RSP), R0	// argc
-	ADD	$8, RSP, R1	// argv
-	BL	main(SB)
+	// The load bias is the distance between where cosmoLinkAddr is
+	// and where it was linked, which is also its unrelocated value.
+	MOVD	$runtime·cosmoLinkAddr(SB), R2
+	MOVD	(R2), R3
+	SUB	R3, R2, R2
+	CBZ	R2, done
+	MOVD	$runtime·rela(SB), R3
+	MOVD	$runtime·erela(SB), R4
+loop:
+	CMP	R4, R3
+	BHS	done
+	MOVWU	8(R3), R5
+	CMP	$1027, R5	// R_AARCH64_RELATIVE
+	BNE	next
+	MOVD	0(R3), R6	// r_offset
+	MOVD	16(R3), R7	// r_addend
+	ADD	R2, R6, R6
+	ADD	R2, R7, R7
+	MOVD	R7, (R6)
+next:
+	ADD	$24, R3, R3
+	B	loop
+done:
+	JMP	_rt0_arm64(SB)
 
 TEXT _rt0_arm64_cosmo_lib(SB),NOSPLIT,$0
-	B	_rt0_arm64_lib(SB)
+	JMP	_rt0_arm64_lib(SB)
 
-TEXT main(SB),NOSPLIT|NOFRAME,$0
-	MOVD	$runtime·rt0_go(SB), R2
-	BL	(R2)
-exit:
-	MOVD	$0, R0
-	MOVD	$94, R8	// SYS_exit_group
-	SVC
-	B	exit
+DATA runtime·cosmoLinkAddr(SB)/8, $runtime·cosmoLinkAddr(SB)
+GLOBL runtime·cosmoLinkAddr(SB), NOPTR, $8
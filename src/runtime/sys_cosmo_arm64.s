@@ -547,7 +547,3 @@ TEXT runtime·sbrk0(SB),NOSPLIT,$0-8
 	SVC
 	MOVD	R0, ret+0(FP)
 	RET
-
-// ARM64 doesn't use settls - TLS is set differently
-TEXT runtime·settls(SB),NOSPLIT,$0
-	RET
@@ -6,242 +6,164 @@
 
 package syscall
 
-import "unsafe"
-
-// SysProcIDMap holds Container ID to Host ID mappings used for User Namespaces.
-type SysProcIDMap struct {
-	ContainerID int // Container ID.
-	HostID      int // Host ID.
-	Size        int // Size.
-}
+import (
+	"internal/hostos"
+	"sync"
+	"unsafe"
+)
 
-type SysProcAttr struct {
-	Chroot     string      // Chroot.
-	Credential *Credential // Credential.
-	// Ptrace tells the child to call ptrace(PTRACE_TRACEME).
-	// Call runtime.LockOSThread before starting a process with this set,
-	// and don't call UnlockOSThread until done with PtraceSyscall calls.
-	Ptrace bool
-	Setsid bool // Create session.
-	// Setpgid sets the process group ID of the child to Pgid,
-	// or, if Pgid == 0, to the new child's process ID.
-	Setpgid bool
-	// Setctty sets the controlling terminal of the child to
-	// file descriptor Ctty. Ctty must be a descriptor number
-	// in the child process: an index into ProcAttr.Files.
-	// This is only meaningful if Setsid is true.
-	Setctty bool
-	Noctty  bool // Detach fd 0 from controlling terminal.
-	Ctty    int  // Controlling TTY fd.
-	// Foreground places the child process group in the foreground.
-	// This implies Setpgid. The Ctty field must be set to
-	// the descriptor of the controlling TTY.
-	// Unlike Setctty, in this case Ctty must be a descriptor
-	// number in the parent process.
-	Foreground bool
-	Pgid       int // Child's process group ID if Setpgid.
-	// Pdeathsig, if non-zero, is a signal that the kernel will send to
-	// the child process when the creating thread dies.
-	Pdeathsig    Signal
-	Cloneflags   uintptr        // Flags for clone calls (not used on cosmo).
-	Unshareflags uintptr        // Flags for unshare calls (not used on cosmo).
-	UidMappings  []SysProcIDMap // User ID mappings for user namespaces.
-	GidMappings  []SysProcIDMap // Group ID mappings for user namespaces.
-	// GidMappingsEnableSetgroups enabling setgroups syscall.
-	GidMappingsEnableSetgroups bool
-	AmbientCaps                []uintptr // Ambient capabilities (not used on cosmo).
-	UseCgroupFD                bool      // Whether to make use of the CgroupFD field.
-	CgroupFD                   int       // File descriptor of a cgroup to put the new process into.
-	PidFD                      *int      // Store pidfd of child if supported.
-}
+// The Linux exec code in exec_linuxabi.go is shared with cosmo. This
+// file adds what a binary that may run on another host, or be an APE
+// itself, needs on top of it.
 
-var (
-	none  = [...]byte{'n', 'o', 'n', 'e', 0}
-	slash = [...]byte{'/', 0}
+const (
+	_SYS_setgroups = SYS_SETGROUPS
+	sys_SETGID     = SYS_SETGID
+	sys_SETUID     = SYS_SETUID
+	fcntl64Syscall = SYS_FCNTL
 )
 
-// Implemented in runtime package.
-func runtime_BeforeFork()
-func runtime_AfterFork()
-func runtime_AfterForkInChild()
-
-// Fork, dup fd onto 0..len(fd), and exec(argv0, argvv, envv) in child.
-// If a dup or exec fails, write the errno error to pipe.
-// (Pipe is close-on-exec so if exec succeeds, it will be closed.)
-// In the child, this function must not acquire any locks, because
-// they might have been locked at the time of the fork. This means
-// no rescheduling, no malloc calls, and no new stack segments.
-//
-//go:norace
-func forkAndExecInChild(argv0 *byte, argv, envv []*byte, chroot, dir *byte, attr *ProcAttr, sys *SysProcAttr, pipe int) (pid int, err Errno) {
-	// Declare all variables at top in case any
-	// temporary variables get allocated during
-	// the actual syscall sequences.
-	var (
-		r1     uintptr
-		err1   Errno
-		nextfd int
-		i      int
-	)
-
-	// About to call fork.
-	// No more allocation or calls of non-assembly functions.
-	runtime_BeforeFork()
-	r1, _, err1 = RawSyscall(SYS_FORK, 0, 0, 0)
-	if err1 != 0 {
-		runtime_AfterFork()
-		return 0, err1
-	}
+var dash = [...]byte{'-', 0}
 
-	if r1 != 0 {
-		// parent; return PID
-		runtime_AfterFork()
-		return int(r1), 0
-	}
+// hostIsLinux reports whether the kernel hosting this binary is Linux.
+// Parent death signals, tracing, namespaces, cgroups, pidfds and
+// vfork-style clone are Linux features; other hosts get a plain fork
+// and SysProcAttr fields asking for the rest are rejected.
+func hostIsLinux() bool {
+	return hostos.Name() == "linux"
+}
 
-	// Fork succeeded, now in child.
-	runtime_AfterForkInChild()
+// apeMagic lists the first bytes of an Actually Portable Executable.
+// They make the file a valid shell script as well as a PE image.
+var apeMagic = [...][8]byte{
+	{'M', 'Z', 'q', 'F', 'p', 'D', '=', '\''},
+	{'j', 'a', 'r', 't', 's', 'r', '=', '\''},
+	{'A', 'P', 'E', 'D', 'B', 'G', '=', '\''},
+}
 
-	// Session ID
-	if sys.Setsid {
-		_, _, err1 = RawSyscall(SYS_SETSID, 0, 0, 0)
-		if err1 != 0 {
-			goto childerror
+// apeInterpreter returns the leading arguments of the command line that
+// runs an APE the kernel won't: an installed APE loader, invoked in the
+// form that preserves argv[0], or else /bin/sh.
+var apeInterpreter = sync.OnceValue(func() []*byte {
+	for _, path := range [...]string{"/usr/bin/ape", "/usr/local/bin/ape"} {
+		var st Stat_t
+		if Stat(path, &st) == nil && st.Mode&S_IFMT == S_IFREG && st.Mode&0111 != 0 {
+			p, _ := BytePtrFromString(path)
+			return []*byte{p, &dash[0]}
 		}
 	}
-
-	// Set process group
-	if sys.Setpgid || sys.Foreground {
-		// Place child in process group.
-		_, _, err1 = RawSyscall(SYS_SETPGID, 0, uintptr(sys.Pgid), 0)
-		if err1 != 0 {
-			goto childerror
-		}
+	p, _ := BytePtrFromString("/bin/sh")
+	return []*byte{p}
+})
+
+// apeArgv returns the nil-terminated argument list that runs the APE
+// at path with arguments argv through apeInterpreter.
+func apeArgv(path *byte, argv []*byte) []*byte {
+	interp := apeInterpreter()
+	args := make([]*byte, 0, len(interp)+1+len(argv))
+	args = append(args, interp...)
+	args = append(args, path)
+	if len(interp) == 1 && len(argv) > 1 {
+		// The shell sets $0 to the script path itself.
+		argv = argv[1:]
 	}
+	return append(args, argv...)
+}
 
-	// Chroot
-	if chroot != nil {
-		_, _, err1 = RawSyscall(SYS_CHROOT, uintptr(unsafe.Pointer(chroot)), 0, 0)
-		if err1 != 0 {
-			goto childerror
-		}
+// isAPE reports whether the file at path begins with apeMagic.
+// It is called in the child after fork, so it must not allocate
+// or grow the stack.
+//
+//go:nosplit
+//go:norace
+func isAPE(path *byte) bool {
+	var magic [8]byte
+	dirfd := int(_AT_FDCWD)
+	fd, _, err := RawSyscall6(SYS_OPENAT, uintptr(dirfd), uintptr(unsafe.Pointer(path)), uintptr(O_RDONLY|O_CLOEXEC), 0, 0, 0)
+	if err != 0 {
+		return false
 	}
-
-	// User and groups
-	if cred := sys.Credential; cred != nil {
-		ngroups := uintptr(len(cred.Groups))
-		groups := uintptr(0)
-		if ngroups > 0 {
-			groups = uintptr(unsafe.Pointer(&cred.Groups[0]))
-		}
-		if !cred.NoSetGroups {
-			_, _, err1 = RawSyscall(SYS_SETGROUPS, ngroups, groups, 0)
-			if err1 != 0 {
-				goto childerror
-			}
-		}
-		_, _, err1 = RawSyscall(SYS_SETGID, uintptr(cred.Gid), 0, 0)
-		if err1 != 0 {
-			goto childerror
-		}
-		_, _, err1 = RawSyscall(SYS_SETUID, uintptr(cred.Uid), 0, 0)
-		if err1 != 0 {
-			goto childerror
-		}
+	n, _, err := RawSyscall(SYS_READ, fd, uintptr(unsafe.Pointer(&magic[0])), uintptr(len(magic)))
+	RawSyscall(SYS_CLOSE, fd, 0, 0)
+	if err != 0 || n != uintptr(len(magic)) {
+		return false
 	}
-
-	// Chdir
-	if dir != nil {
-		_, _, err1 = RawSyscall(SYS_CHDIR, uintptr(unsafe.Pointer(dir)), 0, 0)
-		if err1 != 0 {
-			goto childerror
+	for i := range apeMagic {
+		if magic == apeMagic[i] {
+			return true
 		}
 	}
+	return false
+}
 
-	// Pass 1: look for fd[i] < i and move those up above len(googfd)
-	// so that pass 2 won't stomp on an fd it needs later.
-	if pipe < nextfd {
-		_, _, err1 = RawSyscall(SYS_DUP3, uintptr(pipe), uintptr(nextfd), O_CLOEXEC)
-		if err1 != 0 {
-			goto childerror
-		}
-		pipe = nextfd
-		nextfd++
-	}
-	for i = 0; i < len(attr.Files); i++ {
-		if int(attr.Files[i]) < i {
-			if nextfd == pipe { // don't stomp on pipe
-				nextfd++
-			}
-			_, _, err1 = RawSyscall(SYS_DUP3, attr.Files[i], uintptr(nextfd), O_CLOEXEC)
-			if err1 != 0 {
-				goto childerror
-			}
-			attr.Files[i] = uintptr(nextfd)
-			nextfd++
-		}
-	}
+func init() {
+	execveCosmo = execveAPE
+}
 
-	// Pass 2: dup fd[i] down onto i.
-	for i = 0; i < len(attr.Files); i++ {
-		if attr.Files[i] == uintptr(i) {
-			// dup2(i, i) won't clear close-on-exec flag on Linux,
-			// probably not elsewhere either.
-			_, _, err1 = RawSyscall(SYS_FCNTL, attr.Files[i], F_SETFD, 0)
-			if err1 != 0 {
-				goto childerror
-			}
-			continue
-		}
-		// The new fd is created NOT close-on-exec.
-		_, _, err1 = RawSyscall(SYS_DUP3, attr.Files[i], uintptr(i), 0)
-		if err1 != 0 {
-			goto childerror
-		}
+// execveAPE is execve(2) for Exec, falling back to apeArgv when the
+// kernel rejects an APE with ENOEXEC. If the fallback fails too, its
+// error is returned, so that a missing /bin/sh is not reported as an
+// exec format error.
+func execveAPE(path *byte, argv, envv []*byte) error {
+	_, _, err := RawSyscall(SYS_EXECVE,
+		uintptr(unsafe.Pointer(path)),
+		uintptr(unsafe.Pointer(&argv[0])),
+		uintptr(unsafe.Pointer(&envv[0])))
+	if err == ENOEXEC && isAPE(path) {
+		ape := apeArgv(path, argv)
+		_, _, err = RawSyscall(SYS_EXECVE,
+			uintptr(unsafe.Pointer(ape[0])),
+			uintptr(unsafe.Pointer(&ape[0])),
+			uintptr(unsafe.Pointer(&envv[0])))
 	}
+	return err
+}
 
-	// By convention, we don't close-on-exec the fds we are
-	// started with, so if the caller sets that up,
-	// we have to clean up afterwards.
-	for i = len(attr.Files); i < 3; i++ {
-		RawSyscall(SYS_CLOSE, uintptr(i), 0, 0)
-	}
+// execFallback returns the command line that forkAndExecInChild1
+// retries with if the kernel refuses to execute argv0, which it
+// does for an APE without binfmt_misc support.
+//
+// The child cannot allocate, and finding out whether argv0 is an APE
+// takes three system calls, so the parent builds this argument list
+// for every fork/exec, APE or not: one small allocation beside the
+// ones StartProcess makes for argv and envv anyway.
+func execFallback(argv0 *byte, argv []*byte) []*byte {
+	return apeArgv(argv0, argv)
+}
 
-	// Detach fd 0 from tty
-	if sys.Noctty {
-		_, _, err1 = RawSyscall(SYS_IOCTL, 0, uintptr(TIOCNOTTY), 0)
-		if err1 != 0 {
-			goto childerror
-		}
+// execveFallback is called in the child when execve of argv0 fails
+// with err. Without binfmt_misc support, Linux does not recognize an
+// APE and fails with ENOEXEC; do what a shell does and run it as a
+// script, preferring an installed APE loader. If that fails as well,
+// its error is the one reported to the parent.
+//
+//go:nosplit
+//go:norace
+func execveFallback(argv0 *byte, ape, envv []*byte, err Errno) Errno {
+	if err == ENOEXEC && isAPE(argv0) {
+		_, _, err = RawSyscall(SYS_EXECVE,
+			uintptr(unsafe.Pointer(ape[0])),
+			uintptr(unsafe.Pointer(&ape[0])),
+			uintptr(unsafe.Pointer(&envv[0])))
 	}
+	return err
+}
 
-	// Set the controlling TTY to Ctty
-	if sys.Setctty {
-		_, _, err1 = RawSyscall(SYS_IOCTL, uintptr(sys.Ctty), uintptr(TIOCSCTTY), 1)
-		if err1 != 0 {
-			goto childerror
+// checkSysProcAttr reports ENOTSUP for SysProcAttr fields that cannot
+// be honored on this host, rather than starting a child without them.
+func checkSysProcAttr(sys *SysProcAttr) Errno {
+	if sys.Pdeathsig != 0 || sys.Ptrace || sys.Cloneflags != 0 || sys.Unshareflags != 0 ||
+		sys.UidMappings != nil || sys.GidMappings != nil || sys.UseCgroupFD ||
+		len(sys.AmbientCaps) > 0 {
+		if !hostIsLinux() {
+			return ENOTSUP
 		}
 	}
-
-	// Time to exec.
-	_, _, err1 = RawSyscall(SYS_EXECVE,
-		uintptr(unsafe.Pointer(argv0)),
-		uintptr(unsafe.Pointer(&argv[0])),
-		uintptr(unsafe.Pointer(&envv[0])))
-
-childerror:
-	// send error code on pipe
-	RawSyscall(SYS_WRITE, uintptr(pipe), uintptr(unsafe.Pointer(&err1)), unsafe.Sizeof(err1))
-	for {
-		RawSyscall(SYS_EXIT, 253, 0, 0)
-	}
+	return 0
 }
 
-// forkAndExecFailureCleanup cleans up after an exec failure.
-func forkAndExecFailureCleanup(attr *ProcAttr, sys *SysProcAttr) {
-	if sys.PidFD != nil && *sys.PidFD != -1 {
-		Close(*sys.PidFD)
-		*sys.PidFD = -1
-	}
-}
+// _SYS_clone3 is the same on every architecture cosmo supports.
+const _SYS_clone3 = 435
 
+// Implemented in asm_cosmo_$GOARCH.s.
+func rawVforkSyscall(trap, a1, a2, a3 uintptr) (r1 uintptr, err Errno)
@@ -267,6 +267,9 @@ func runtime_AfterExec()
 // avoids a build dependency for other platforms.
 var execveLibc func(path *byte, argv **byte, envp **byte) error
 
+// execveCosmo is non-nil on cosmo, set to execveAPE in exec_cosmo.go.
+var execveCosmo func(path *byte, argv []*byte, envp []*byte) error
+
 // Exec invokes the execve(2) system call.
 func Exec(argv0 string, argv []string, envv []string) (err error) {
 	argv0p, err := BytePtrFromString(argv0)
@@ -294,6 +297,9 @@ func Exec(argv0 string, argv []string, envv []string) (err error) {
 		// RawSyscall should never be used on these platforms.
 		err1 = execveLibc(argv0p, &argvp[0], &envvp[0])
 
+	case "cosmo":
+		err1 = execveCosmo(argv0p, argvp, envvp)
+
 	default:
 		_, _, err1 = RawSyscall(SYS_EXECVE,
 			uintptr(unsafe.Pointer(argv0p)),
@@ -0,0 +1,30 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package syscall
+
+import (
+	"unsafe"
+)
+
+var (
+	ForceClone3    = &forceClone3
+	APEInterpreter = &apeInterpreter
+)
+
+func Tcgetpgrp(fd int) (pgid int32, err error) {
+	_, _, errno := Syscall6(SYS_IOCTL, uintptr(fd), uintptr(TIOCGPGRP), uintptr(unsafe.Pointer(&pgid)), 0, 0, 0)
+	if errno != 0 {
+		return -1, errno
+	}
+	return pgid, nil
+}
+
+func Tcsetpgrp(fd int, pgid int32) (err error) {
+	_, _, errno := Syscall6(SYS_IOCTL, uintptr(fd), uintptr(TIOCSPGRP), uintptr(unsafe.Pointer(&pgid)), 0, 0, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -122,6 +122,16 @@ aix_ppc64)
 	mksyscall="./mksyscall_libc.pl -aix"
 	mktypes="GOARCH=$GOARCH go tool cgo -godefs"
 	;;
+cosmo_amd64 | cosmo_arm64)
+	# Cosmopolitan uses the Linux system call ABI and structure layouts,
+	# so the error, system call number and type tables are derived from
+	# the checked-in Linux ones by mkcosmo.go, which runs on the host
+	# rather than the target. See mkcosmo.go for why.
+	mkcosmo="GOOS=$(go env GOHOSTOS) GOARCH=$(go env GOHOSTARCH) go run mkcosmo.go"
+	mkerrors="$mkcosmo errors $GOARCH"
+	mksysnum="$mkcosmo sysnum $GOARCH"
+	mktypes="$mkcosmo types $GOARCH"
+	;;
 darwin_amd64)
 	mkerrors="$mkerrors -m64"
 	mksyscall="./mksyscall.pl -darwin"
@@ -413,7 +423,9 @@ esac
 	if [ -n "$mksyscall" ]; then echo "$mksyscall -tags $GOOS,$GOARCH $syscall_goos $GOOSARCH_in |gofmt >zsyscall_$GOOSARCH.go"; fi
 	if [ -n "$mksysctl" ]; then echo "$mksysctl |gofmt >$zsysctl"; fi
 	if [ -n "$mksysnum" ]; then echo "$mksysnum |gofmt >zsysnum_$GOOSARCH.go"; fi
-	if [ -n "$mktypes" ]; then
+	if [ -n "$mktypes" ] && [ "$GOOS" = cosmo ]; then
+		echo "$mktypes |gofmt >ztypes_$GOOSARCH.go"
+	elif [ -n "$mktypes" ]; then
 		# ztypes_$GOOSARCH.go could be erased before "go run mkpost.go" is called.
 		# Therefore, "go run" tries to recompile syscall package but ztypes is empty and it fails.
 		echo "$mktypes types_$GOOS.go |go run mkpost.go >ztypes_$GOOSARCH.go.NEW && mv ztypes_$GOOSARCH.go.NEW ztypes_$GOOSARCH.go";
@@ -0,0 +1,79 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build ignore
+
+// mkcosmo.go generates the cosmo error, system call number and type
+// tables.
+//
+// Cosmopolitan uses the Linux system call ABI and Linux structure
+// layouts on every host, so the tables are copies of the checked-in
+// zerrors_linux_$GOARCH.go, zsysnum_linux_$GOARCH.go and
+// ztypes_linux_$GOARCH.go files, which mkerrors.sh, mksysnum_linux.pl
+// and cgo -godefs generate from the Linux UAPI and C library headers.
+// Running cgo -godefs against the cosmocc headers instead would need
+// the cosmocc toolchain, and would describe the C library's structures
+// rather than the kernel ones that the system calls fill in. Deriving
+// the tables this way needs neither network access nor a Cosmopolitan
+// machine, and keeps the cosmo syscall API the same as the Linux one.
+//
+// Usage:
+//
+//	go run mkcosmo.go errors|sysnum|types <goarch>
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("mkcosmo: ")
+	if len(os.Args) != 3 {
+		log.Fatalf("usage: go run mkcosmo.go errors|sysnum|types <goarch>")
+	}
+	kind, arch := os.Args[1], os.Args[2]
+	switch kind {
+	case "errors", "sysnum", "types":
+	default:
+		log.Fatalf("unknown table %q", kind)
+	}
+	switch arch {
+	case "amd64", "arm64":
+	default:
+		log.Fatalf("unsupported GOARCH %q", arch)
+	}
+
+	file := fmt.Sprintf("z%s_linux_%s.go", kind, arch)
+	src, err := os.ReadFile(file)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, src, parser.PackageClauseOnly)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// go run mkcosmo.go %s %s\n", kind, arch)
+	fmt.Fprintf(&buf, "// Code generated by the command above; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "//go:build cosmo && %s\n\n", arch)
+	fmt.Fprintf(&buf, "package syscall\n")
+	// Copy everything after the package clause, leaving the Linux
+	// header and build constraint behind.
+	buf.Write(src[fset.Position(f.Name.End()).Offset:])
+
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	os.Stdout.Write(out)
+}
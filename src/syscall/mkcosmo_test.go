@@ -0,0 +1,76 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// The generators only need a host with a Go toolchain and perl, but
+// checking the cosmo tables once, on Linux builders, is enough.
+
+//go:build linux
+
+package syscall_test
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"internal/testenv"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// TestCosmoGeneratedFiles checks that the cosmo z-files match what
+// mkall.sh would generate for them.
+func TestCosmoGeneratedFiles(t *testing.T) {
+	testenv.MustHaveGoRun(t)
+	_, perlErr := exec.LookPath("perl")
+
+	// mkcosmo.go runs on the host, like it does under mkall.sh.
+	out, err := testenv.Command(t, testenv.GoToolPath(t), "env", "GOHOSTOS", "GOHOSTARCH").Output()
+	if err != nil {
+		t.Fatalf("go env: %v", err)
+	}
+	host := strings.Fields(string(out))
+	if len(host) != 2 {
+		t.Fatalf("go env GOHOSTOS GOHOSTARCH: unexpected output %q", out)
+	}
+
+	for _, arch := range []string{"amd64", "arm64"} {
+		t.Run(arch, func(t *testing.T) {
+			for _, kind := range []string{"errors", "sysnum", "types"} {
+				cmd := testenv.Command(t, testenv.GoToolPath(t), "run", "mkcosmo.go", kind, arch)
+				cmd.Env = append(cmd.Environ(), "GOOS="+host[0], "GOARCH="+host[1])
+				checkGenerated(t, fmt.Sprintf("z%s_cosmo_%s.go", kind, arch), cmd)
+			}
+			if perlErr != nil {
+				t.Logf("skipping zsyscall_cosmo_%s.go: %v", arch, perlErr)
+				return
+			}
+			cmd := testenv.Command(t, "perl", "mksyscall.pl", "-tags", "cosmo,"+arch, "syscall_cosmo.go", "syscall_cosmo_"+arch+".go")
+			cmd.Env = append(cmd.Environ(), "GOOS=cosmo", "GOARCH="+arch)
+			checkGenerated(t, "zsyscall_cosmo_"+arch+".go", cmd)
+		})
+	}
+}
+
+func checkGenerated(t *testing.T, file string, cmd *exec.Cmd) {
+	t.Helper()
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("%v: %v\n%s", cmd, err, stderr.Bytes())
+	}
+	want, err := format.Source(out)
+	if err != nil {
+		t.Fatalf("%v: formatting output: %v", cmd, err)
+	}
+	got, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s is out of date; regenerate it with mkall.sh", file)
+	}
+}
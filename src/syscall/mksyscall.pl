@@ -237,7 +237,7 @@ while(<>) {
 	# Determine which form to use; pad args with zeros.
 	my $asm = "Syscall";
 	if ($nonblock) {
-		if ($errvar eq "" && $ENV{'GOOS'} eq "linux") {
+		if ($errvar eq "" && ($ENV{'GOOS'} eq "linux" || $ENV{'GOOS'} eq "cosmo")) {
 			$asm = "rawSyscallNoError";
 		} else {
 			$asm = "RawSyscall";
@@ -342,7 +342,7 @@ while(<>) {
 	if ($ret[0] eq "_" && $ret[1] eq "_" && $ret[2] eq "_") {
 		$text .= "\t$call\n";
 	} else {
-		if ($errvar eq "" && $ENV{'GOOS'} eq "linux") {
+		if ($errvar eq "" && ($ENV{'GOOS'} eq "linux" || $ENV{'GOOS'} eq "cosmo")) {
 			# raw syscall without error on Linux, see golang.org/issue/22924
 			$text .= "\t$ret[0], $ret[1] := $call\n";
 		} else {
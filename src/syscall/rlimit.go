@@ -38,7 +38,7 @@ func init() {
 		// to change our resource limits. The theory is that
 		// using prlimit to change to Cur == Max is more likely
 		// than using prlimit to change to Cur == Max - 1.
-		// The place we check for this is in exec_linux.go.
+		// The place we check for this is in exec_linuxabi.go.
 		nlim.Cur = nlim.Max - 1
 
 		adjustFileLimit(&nlim)
@@ -0,0 +1,270 @@
+// Copyright 2024 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package syscall_test
+
+import (
+	"fmt"
+	"internal/testenv"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"unsafe"
+)
+
+func TestMain(m *testing.M) {
+	if os.Getenv("GO_DEATHSIG_PARENT") == "1" {
+		deathSignalParent()
+	} else if os.Getenv("GO_DEATHSIG_CHILD") == "1" {
+		deathSignalChild()
+	}
+
+	os.Exit(m.Run())
+}
+
+// TestAllThreadsSyscallCosmo checks that AllThreadsSyscall reaches
+// threads that are locked to other goroutines.
+func TestAllThreadsSyscallCosmo(t *testing.T) {
+	const (
+		PR_GET_KEEPCAPS uintptr = 7
+		PR_SET_KEEPCAPS         = 8
+	)
+	if _, _, err := syscall.AllThreadsSyscall(syscall.SYS_PRCTL, PR_SET_KEEPCAPS, 0, 0); err != 0 {
+		t.Skipf("AllThreadsSyscall: %v", err)
+	}
+
+	const threads = 8
+	var ready, done sync.WaitGroup
+	check := make(chan struct{})
+	ready.Add(threads)
+	done.Add(threads)
+	for i := 0; i < threads; i++ {
+		go func() {
+			defer done.Done()
+			runtime.LockOSThread()
+			defer runtime.UnlockOSThread()
+			ready.Done()
+			<-check
+			v, _, e := syscall.Syscall(syscall.SYS_PRCTL, PR_GET_KEEPCAPS, 0, 0)
+			if e != 0 {
+				t.Errorf("tid=%d prctl(PR_GET_KEEPCAPS) failed: %v", syscall.Gettid(), e)
+			} else if v != 1 {
+				t.Errorf("tid=%d prctl(PR_GET_KEEPCAPS) = %d, want 1", syscall.Gettid(), v)
+			}
+		}()
+	}
+	ready.Wait()
+	if _, _, err := syscall.AllThreadsSyscall(syscall.SYS_PRCTL, PR_SET_KEEPCAPS, 1, 0); err != 0 {
+		t.Fatalf("AllThreadsSyscall(PR_SET_KEEPCAPS, 1): %v", err)
+	}
+	close(check)
+	done.Wait()
+	syscall.AllThreadsSyscall(syscall.SYS_PRCTL, PR_SET_KEEPCAPS, 0, 0)
+}
+
+func TestForkExecUnsupportedAttrCosmo(t *testing.T) {
+	if goos, _ := os.HostOS(); goos == "linux" {
+		t.Skip("Linux hosts support ambient capabilities; see TestAmbientCaps")
+	}
+	attr := &syscall.ProcAttr{
+		Sys: &syscall.SysProcAttr{AmbientCaps: []uintptr{0}},
+	}
+	_, err := syscall.ForkExec("/bin/true", []string{"true"}, attr)
+	if err != syscall.ENOTSUP {
+		t.Errorf("ForkExec with AmbientCaps = %v, want %v", err, syscall.ENOTSUP)
+	}
+}
+
+// TestInotifyCosmo exercises a few of the Linux-only calls that cosmo
+// shares with linux.
+func TestInotifyCosmo(t *testing.T) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC)
+	if err != nil {
+		t.Skipf("InotifyInit1: %v", err)
+	}
+	defer syscall.Close(fd)
+
+	dir := t.TempDir()
+	if _, err := syscall.InotifyAddWatch(fd, dir, syscall.IN_CREATE); err != nil {
+		t.Fatalf("InotifyAddWatch: %v", err)
+	}
+	f, err := syscall.Open(dir+"/f", syscall.O_CREAT|syscall.O_WRONLY|syscall.O_CLOEXEC, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	syscall.Close(f)
+
+	var buf [syscall.SizeofInotifyEvent + syscall.PathMax]byte
+	n, err := syscall.Read(fd, buf[:])
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if n < syscall.SizeofInotifyEvent {
+		t.Fatalf("short inotify read: %d bytes", n)
+	}
+	ev := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[0]))
+	if ev.Mask&syscall.IN_CREATE == 0 {
+		t.Errorf("event mask = %#x; want IN_CREATE", ev.Mask)
+	}
+
+	var ru syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &ru); err != nil {
+		t.Errorf("Getrusage: %v", err)
+	}
+	var si syscall.Sysinfo_t
+	if err := syscall.Sysinfo(&si); err != nil {
+		t.Errorf("Sysinfo: %v", err)
+	} else if si.Uptime <= 0 {
+		t.Errorf("Sysinfo reported uptime %d", si.Uptime)
+	}
+}
+
+// apeScript is an APE in miniature: the magic makes it a shell script
+// that the kernel refuses with ENOEXEC.
+const apeScript = "MZqFpD='\n'\necho \"$0|$*\"\n"
+
+// TestExecAPECosmo checks that Exec and ForkExec retry an APE that the
+// kernel won't run through /bin/sh.
+func TestExecAPECosmo(t *testing.T) {
+	const helperEnvVar = "GO_WANT_APE_EXEC"
+	if path := os.Getenv(helperEnvVar); path != "" {
+		os.Unsetenv(helperEnvVar)
+		err := syscall.Exec(path, []string{"prog", "a", "b c"}, os.Environ())
+		t.Fatalf("Exec: %v", err)
+	}
+
+	for _, ape := range []string{"/usr/bin/ape", "/usr/local/bin/ape"} {
+		if _, err := os.Stat(ape); err == nil {
+			t.Skipf("skipping: %s would run the script instead of /bin/sh", ape)
+		}
+	}
+	path := filepath.Join(t.TempDir(), "script.com")
+	if err := os.WriteFile(path, []byte(apeScript), 0755); err != nil {
+		t.Fatal(err)
+	}
+	want := path + "|a b c"
+
+	cmd := testenv.Command(t, testenv.Executable(t), "-test.run=^"+t.Name()+"$")
+	cmd.Env = append(cmd.Environ(), helperEnvVar+"="+path)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("%v: %v\n%s", cmd, err, out)
+	}
+	if got := strings.TrimSpace(string(out)); got != want {
+		t.Errorf("Exec output = %q; want %q", got, want)
+	}
+
+	out, err = exec.Command(path, "a", "b c").CombinedOutput()
+	if err != nil {
+		t.Fatalf("ForkExec: %v\n%s", err, out)
+	}
+	if got := strings.TrimSpace(string(out)); got != want {
+		t.Errorf("ForkExec output = %q; want %q", got, want)
+	}
+
+	// Other files that the kernel can't run still fail.
+	if err := os.WriteFile(path, []byte("\x00\x01\x02\x03not an executable\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := exec.Command(path).Run(); err == nil {
+		t.Error("running a file without the APE magic succeeded")
+	}
+}
+
+// TestExecAPEFallbackError checks that when the kernel rejects an APE
+// and the interpreter that should run it instead is missing, Exec and
+// ForkExec report the missing interpreter rather than ENOEXEC.
+func TestExecAPEFallbackError(t *testing.T) {
+	const helperEnvVar = "GO_WANT_APE_EXEC_MISSING"
+	missingInterpreter := func(t *testing.T) {
+		sh, err := syscall.BytePtrFromString(filepath.Join(t.TempDir(), "sh"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		orig := *syscall.APEInterpreter
+		*syscall.APEInterpreter = func() []*byte { return []*byte{sh} }
+		t.Cleanup(func() { *syscall.APEInterpreter = orig })
+	}
+	if path := os.Getenv(helperEnvVar); path != "" {
+		os.Unsetenv(helperEnvVar)
+		missingInterpreter(t)
+		fmt.Print(syscall.Exec(path, []string{path}, os.Environ()))
+		os.Exit(0)
+	}
+
+	path := filepath.Join(t.TempDir(), "script.com")
+	if err := os.WriteFile(path, []byte(apeScript), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := testenv.Command(t, testenv.Executable(t), "-test.run=^"+t.Name()+"$")
+	cmd.Env = append(cmd.Environ(), helperEnvVar+"="+path)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("%v: %v\n%s", cmd, err, out)
+	}
+	if got, want := string(out), syscall.ENOENT.Error(); got != want {
+		t.Errorf("Exec error = %q; want %q", got, want)
+	}
+
+	missingInterpreter(t)
+	_, err = syscall.ForkExec(path, []string{path}, &syscall.ProcAttr{})
+	if err != syscall.ENOENT {
+		t.Errorf("ForkExec = %v; want %v", err, syscall.ENOENT)
+	}
+}
+
+// TestPdeathsigCosmo checks that a child started with Pdeathsig is
+// killed when the process that started it exits.
+func TestPdeathsigCosmo(t *testing.T) {
+	const helperEnvVar = "GO_WANT_PDEATHSIG_HELPER"
+	if os.Getenv(helperEnvVar) != "" {
+		cmd := exec.Command("sleep", "60")
+		cmd.SysProcAttr = &syscall.SysProcAttr{Pdeathsig: syscall.SIGKILL}
+		if err := cmd.Start(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Println(cmd.Process.Pid)
+		os.Exit(0)
+	}
+
+	testenv.MustHaveExecPath(t, "sleep")
+	if goos, _ := os.HostOS(); goos != "linux" {
+		t.Skipf("skipping on %s host: Pdeathsig needs Linux", goos)
+	}
+
+	// Adopt the orphaned grandchild, so that its exit status can
+	// be collected here.
+	const PR_SET_CHILD_SUBREAPER = 36
+	if _, _, e := syscall.RawSyscall(syscall.SYS_PRCTL, PR_SET_CHILD_SUBREAPER, 1, 0); e != 0 {
+		t.Skipf("prctl(PR_SET_CHILD_SUBREAPER): %v", e)
+	}
+	defer syscall.RawSyscall(syscall.SYS_PRCTL, PR_SET_CHILD_SUBREAPER, 0, 0)
+
+	cmd := testenv.Command(t, testenv.Executable(t), "-test.run=^"+t.Name()+"$")
+	cmd.Env = append(cmd.Environ(), helperEnvVar+"=1")
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("%v: %v", cmd, err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(out)))
+	if err != nil {
+		t.Fatalf("bad helper output %q", out)
+	}
+
+	var ws syscall.WaitStatus
+	if _, err := syscall.Wait4(pid, &ws, 0, nil); err != nil {
+		t.Fatalf("Wait4(%d): %v", pid, err)
+	}
+	if !ws.Signaled() || ws.Signal() != syscall.SIGKILL {
+		t.Errorf("child of exited process ended with status %#x; want killed by SIGKILL", ws)
+	}
+}
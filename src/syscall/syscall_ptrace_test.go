@@ -2,7 +2,7 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+//go:build cosmo || darwin || dragonfly || freebsd || linux || netbsd || openbsd
 
 package syscall_test
 
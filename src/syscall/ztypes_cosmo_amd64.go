@@ -1,9 +1,5 @@
-// Copyright 2024 The Go Authors. All rights reserved.
-// Use of this source code is governed by a BSD-style
-// license that can be found in the LICENSE file.
-
-// Type definitions for Cosmopolitan Libc on amd64.
-// Cosmopolitan uses Linux-style structures.
+// go run mkcosmo.go types amd64
+// Code generated by the command above; DO NOT EDIT.
 
 //go:build cosmo && amd64
 
@@ -103,21 +99,21 @@ type Rlimit struct {
 type _Gid_t uint32
 
 type Stat_t struct {
-	Dev     uint64
-	Ino     uint64
-	Nlink   uint64
-	Mode    uint32
-	Uid     uint32
-	Gid     uint32
-	X__pad0 int32
-	Rdev    uint64
-	Size    int64
-	Blksize int64
-	Blocks  int64
-	Atim    Timespec
-	Mtim    Timespec
-	Ctim    Timespec
-	_       [3]int64
+	Dev       uint64
+	Ino       uint64
+	Nlink     uint64
+	Mode      uint32
+	Uid       uint32
+	Gid       uint32
+	X__pad0   int32
+	Rdev      uint64
+	Size      int64
+	Blksize   int64
+	Blocks    int64
+	Atim      Timespec
+	Mtim      Timespec
+	Ctim      Timespec
+	X__unused [3]int64
 }
 
 type Statfs_t struct {
@@ -359,12 +355,17 @@ const (
 	IFLA_WEIGHT         = 0xf
 	IFLA_OPERSTATE      = 0x10
 	IFLA_LINKMODE       = 0x11
+	IFLA_LINKINFO       = 0x12
+	IFLA_NET_NS_PID     = 0x13
+	IFLA_IFALIAS        = 0x14
+	IFLA_MAX            = 0x1d
 	RT_SCOPE_UNIVERSE   = 0x0
 	RT_SCOPE_SITE       = 0xc8
 	RT_SCOPE_LINK       = 0xfd
 	RT_SCOPE_HOST       = 0xfe
 	RT_SCOPE_NOWHERE    = 0xff
 	RT_TABLE_UNSPEC     = 0x0
+	RT_TABLE_COMPAT     = 0xfc
 	RT_TABLE_DEFAULT    = 0xfd
 	RT_TABLE_MAIN       = 0xfe
 	RT_TABLE_LOCAL      = 0xff
@@ -394,6 +395,22 @@ const (
 	RTN_THROW           = 0x9
 	RTN_NAT             = 0xa
 	RTN_XRESOLVE        = 0xb
+	RTNLGRP_NONE        = 0x0
+	RTNLGRP_LINK        = 0x1
+	RTNLGRP_NOTIFY      = 0x2
+	RTNLGRP_NEIGH       = 0x3
+	RTNLGRP_TC          = 0x4
+	RTNLGRP_IPV4_IFADDR = 0x5
+	RTNLGRP_IPV4_MROUTE = 0x6
+	RTNLGRP_IPV4_ROUTE  = 0x7
+	RTNLGRP_IPV4_RULE   = 0x8
+	RTNLGRP_IPV6_IFADDR = 0x9
+	RTNLGRP_IPV6_MROUTE = 0xa
+	RTNLGRP_IPV6_ROUTE  = 0xb
+	RTNLGRP_IPV6_IFINFO = 0xc
+	RTNLGRP_IPV6_PREFIX = 0x12
+	RTNLGRP_IPV6_RULE   = 0x13
+	RTNLGRP_ND_USEROPT  = 0x14
 	SizeofNlMsghdr      = 0x10
 	SizeofNlMsgerr      = 0x14
 	SizeofRtGenmsg      = 0x1
@@ -468,6 +485,11 @@ type RtNexthop struct {
 	Ifindex int32
 }
 
+const (
+	SizeofSockFilter = 0x8
+	SizeofSockFprog  = 0x10
+)
+
 type SockFilter struct {
 	Code uint16
 	Jt   uint8
@@ -486,8 +508,11 @@ type InotifyEvent struct {
 	Mask   uint32
 	Cookie uint32
 	Len    uint32
+	Name   [0]uint8
 }
 
+const SizeofInotifyEvent = 0x10
+
 type PtraceRegs struct {
 	R15      uint64
 	R14      uint64
@@ -537,17 +562,17 @@ type Sysinfo_t struct {
 	Totalhigh uint64
 	Freehigh  uint64
 	Unit      uint32
-	X_f       [0]int8
+	X_f       [0]byte
 	Pad_cgo_1 [4]byte
 }
 
 type Utsname struct {
-	Sysname    [65]byte
-	Nodename   [65]byte
-	Release    [65]byte
-	Version    [65]byte
-	Machine    [65]byte
-	Domainname [65]byte
+	Sysname    [65]int8
+	Nodename   [65]int8
+	Release    [65]int8
+	Version    [65]int8
+	Machine    [65]int8
+	Domainname [65]int8
 }
 
 type Ustat_t struct {
@@ -566,108 +591,129 @@ type EpollEvent struct {
 }
 
 const (
-	AT_FDCWD            = -0x64
-	AT_REMOVEDIR        = 0x200
-	AT_SYMLINK_FOLLOW   = 0x400
-	AT_SYMLINK_NOFOLLOW = 0x100
+	_AT_FDCWD            = -0x64
+	_AT_REMOVEDIR        = 0x200
+	_AT_SYMLINK_NOFOLLOW = 0x100
+	_AT_EACCESS          = 0x200
+	_AT_EMPTY_PATH       = 0x1000
 )
 
-type PollFd struct {
+type pollFd struct {
 	Fd      int32
 	Events  int16
 	Revents int16
 }
 
-const (
-	POLLIN    = 0x1
-	POLLPRI   = 0x2
-	POLLOUT   = 0x4
-	POLLRDHUP = 0x2000
-	POLLERR   = 0x8
-	POLLHUP   = 0x10
-	POLLNVAL  = 0x20
-)
-
-type Sigset_t struct {
-	X__val [16]uint64
-}
-
-const RNDGETENTCNT = 0x80045200
-
-const PERF_IOC_FLAG_GROUP = 0x1
-
 type Termios struct {
 	Iflag     uint32
 	Oflag     uint32
 	Cflag     uint32
 	Lflag     uint32
 	Line      uint8
-	Cc        [19]uint8
+	Cc        [32]uint8
+	Pad_cgo_0 [3]byte
 	Ispeed    uint32
 	Ospeed    uint32
 }
 
-type Winsize struct {
-	Row    uint16
-	Col    uint16
-	Xpixel uint16
-	Ypixel uint16
-}
-
 const (
-	IUCLC  = 0x200
-	OLCUC  = 0x2
-	TCGETS = 0x5401
-	TCSETS = 0x5402
-	XCASE  = 0x4
+	VINTR    = 0x0
+	VQUIT    = 0x1
+	VERASE   = 0x2
+	VKILL    = 0x3
+	VEOF     = 0x4
+	VTIME    = 0x5
+	VMIN     = 0x6
+	VSWTC    = 0x7
+	VSTART   = 0x8
+	VSTOP    = 0x9
+	VSUSP    = 0xa
+	VEOL     = 0xb
+	VREPRINT = 0xc
+	VDISCARD = 0xd
+	VWERASE  = 0xe
+	VLNEXT   = 0xf
+	VEOL2    = 0x10
+	IGNBRK   = 0x1
+	BRKINT   = 0x2
+	IGNPAR   = 0x4
+	PARMRK   = 0x8
+	INPCK    = 0x10
+	ISTRIP   = 0x20
+	INLCR    = 0x40
+	IGNCR    = 0x80
+	ICRNL    = 0x100
+	IUCLC    = 0x200
+	IXON     = 0x400
+	IXANY    = 0x800
+	IXOFF    = 0x1000
+	IMAXBEL  = 0x2000
+	IUTF8    = 0x4000
+	OPOST    = 0x1
+	OLCUC    = 0x2
+	ONLCR    = 0x4
+	OCRNL    = 0x8
+	ONOCR    = 0x10
+	ONLRET   = 0x20
+	OFILL    = 0x40
+	OFDEL    = 0x80
+	B0       = 0x0
+	B50      = 0x1
+	B75      = 0x2
+	B110     = 0x3
+	B134     = 0x4
+	B150     = 0x5
+	B200     = 0x6
+	B300     = 0x7
+	B600     = 0x8
+	B1200    = 0x9
+	B1800    = 0xa
+	B2400    = 0xb
+	B4800    = 0xc
+	B9600    = 0xd
+	B19200   = 0xe
+	B38400   = 0xf
+	CSIZE    = 0x30
+	CS5      = 0x0
+	CS6      = 0x10
+	CS7      = 0x20
+	CS8      = 0x30
+	CSTOPB   = 0x40
+	CREAD    = 0x80
+	PARENB   = 0x100
+	PARODD   = 0x200
+	HUPCL    = 0x400
+	CLOCAL   = 0x800
+	B57600   = 0x1001
+	B115200  = 0x1002
+	B230400  = 0x1003
+	B460800  = 0x1004
+	B500000  = 0x1005
+	B576000  = 0x1006
+	B921600  = 0x1007
+	B1000000 = 0x1008
+	B1152000 = 0x1009
+	B1500000 = 0x100a
+	B2000000 = 0x100b
+	B2500000 = 0x100c
+	B3000000 = 0x100d
+	B3500000 = 0x100e
+	B4000000 = 0x100f
+	ISIG     = 0x1
+	ICANON   = 0x2
+	XCASE    = 0x4
+	ECHO     = 0x8
+	ECHOE    = 0x10
+	ECHOK    = 0x20
+	ECHONL   = 0x40
+	NOFLSH   = 0x80
+	TOSTOP   = 0x100
+	ECHOCTL  = 0x200
+	ECHOPRT  = 0x400
+	ECHOKE   = 0x800
+	FLUSHO   = 0x1000
+	PENDIN   = 0x4000
+	IEXTEN   = 0x8000
+	TCGETS   = 0x5401
+	TCSETS   = 0x5402
 )
-
-type Taskstats struct {
-	Version                   uint16
-	Pad_cgo_0                 [2]byte
-	Ac_exitcode               uint32
-	Ac_flag                   uint8
-	Ac_nice                   uint8
-	Pad_cgo_1                 [6]byte
-	Cpu_count                 uint64
-	Cpu_delay_total           uint64
-	Blkio_count               uint64
-	Blkio_delay_total         uint64
-	Swapin_count              uint64
-	Swapin_delay_total        uint64
-	Cpu_run_real_total        uint64
-	Cpu_run_virtual_total     uint64
-	Ac_comm                   [32]int8
-	Ac_sched                  uint8
-	Ac_pad                    [3]uint8
-	Pad_cgo_2                 [4]byte
-	Ac_uid                    uint32
-	Ac_gid                    uint32
-	Ac_pid                    uint32
-	Ac_ppid                   uint32
-	Ac_btime                  uint32
-	Pad_cgo_3                 [4]byte
-	Ac_etime                  uint64
-	Ac_utime                  uint64
-	Ac_stime                  uint64
-	Ac_minflt                 uint64
-	Ac_majflt                 uint64
-	Coremem                   uint64
-	Virtmem                   uint64
-	Hiwater_rss               uint64
-	Hiwater_vm                uint64
-	Read_char                 uint64
-	Write_char                uint64
-	Read_syscalls             uint64
-	Write_syscalls            uint64
-	Read_bytes                uint64
-	Write_bytes               uint64
-	Cancelled_write_bytes     uint64
-	Nvcsw                     uint64
-	Nivcsw                    uint64
-	Ac_utimescaled            uint64
-	Ac_stimescaled            uint64
-	Cpu_scaled_run_real_total uint64
-	Freepages_count           uint64
-	Freepages_delay_total     uint64
-}